@@ -0,0 +1,115 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NotifierTargetTypeWebhook = "webhook"
+	NotifierTargetTypeSlack   = "slack"
+	NotifierTargetTypeTeams   = "teams"
+	NotifierTargetTypeEmail   = "email"
+)
+
+// NotifierVerdict is a run's verdict the notifications are sent for.
+// +kubebuilder:validation:Enum=pass;skip;fail;error
+type NotifierVerdict string
+
+// NotifierTarget defines where the notifications are sent.
+// +kubebuilder:validation:XValidation:rule="self.type == 'email' ? has(self.email) : (has(self.url) != has(self.urlSecretRef))",message="email targets need email, the other ones exactly one of url and urlSecretRef"
+type NotifierTarget struct {
+	// Name identifies the target in the runs' delivery status.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Type of the target: "webhook" posts the run's results as json, "slack" and "teams" post
+	// the message to an incoming webhook and "email" sends it by SMTP.
+	//+kubebuilder:validation:Enum=webhook;slack;teams;email
+	Type string `json:"type"`
+	// URL of the webhook.
+	URL string `json:"url,omitempty"`
+	// URLSecretRef references the key of a secret, in the notifier's namespace, holding the URL
+	// of the webhook, as incoming webhook URLs are usually secret.
+	URLSecretRef *corev1.SecretKeySelector `json:"urlSecretRef,omitempty"`
+	// Email holds the settings of the email targets.
+	Email *EmailTarget `json:"email,omitempty"`
+}
+
+// EmailTarget defines the SMTP server and recipients of an email target.
+type EmailTarget struct {
+	// SMTPServer holds the address of the SMTP server, as host:port.
+	//+kubebuilder:validation:MinLength=1
+	SMTPServer string `json:"smtpServer"`
+	// From holds the sender's address.
+	//+kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// To holds the recipients' addresses.
+	//+kubebuilder:validation:MinItems=1
+	To []string `json:"to"`
+	// CredentialsSecretName holds the name of a secret, in the notifier's namespace, with the
+	// "username" and "password" keys to authenticate in the SMTP server. If not set, no
+	// authentication is used.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// CnfCertificationNotifierSpec defines the notifications sent when the runs finish.
+type CnfCertificationNotifierSpec struct {
+	// RunSelector selects the runs, in the notifier's namespace, that are notified. If not set,
+	// all of them are notified.
+	RunSelector *metav1.LabelSelector `json:"runSelector,omitempty"`
+	// Verdicts filters the runs by their verdict. Runs that finish without report have no verdict
+	// and are notified as "error". If empty, runs with any verdict are notified.
+	Verdicts []NotifierVerdict `json:"verdicts,omitempty"`
+	// MessageTemplate holds a Go template for the message, rendered with the run's name,
+	// namespace, phase, verdict, summary and top failures. If not set, a default message is used.
+	MessageTemplate string `json:"messageTemplate,omitempty"`
+	// MaxAttempts holds the number of times a notification is tried before giving up.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=10
+	//+kubebuilder:default=3
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// Targets holds where the notifications are sent.
+	//+kubebuilder:validation:MinItems=1
+	Targets []NotifierTarget `json:"targets"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Targets",type="string",JSONPath=".spec.targets[*].name"
+
+// CnfCertificationNotifier is the Schema for the cnfcertificationnotifiers API
+type CnfCertificationNotifier struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CnfCertificationNotifierSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CnfCertificationNotifierList contains a list of CnfCertificationNotifier
+type CnfCertificationNotifierList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CnfCertificationNotifier `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CnfCertificationNotifier{}, &CnfCertificationNotifierList{})
+}
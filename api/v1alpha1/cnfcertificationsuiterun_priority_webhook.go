@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	priorityWebhookPath = "/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun-priority"
+	// Virtual subresource users must be allowed to update in order to set a run's priority.
+	prioritySubresource = "priority"
+)
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun-priority,mutating=false,failurePolicy=fail,sideEffects=None,groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=create;update,versions=v1alpha1,name=vcnfcertificationsuiterunpriority.kb.io,admissionReviewVersions=v1
+
+// priorityValidator only admits run CRs setting or changing spec.priority if the requesting user
+// is allowed to update the cnfcertificationsuiteruns/priority subresource, so only admins can
+// prioritize runs.
+type priorityValidator struct {
+	client  client.Client
+	decoder admission.Decoder
+}
+
+func newPriorityWebhook(cl client.Client, scheme *runtime.Scheme) *webhook.Admission {
+	return &webhook.Admission{Handler: &priorityValidator{client: cl, decoder: admission.NewDecoder(scheme)}}
+}
+
+func (v *priorityValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	run := CnfCertificationSuiteRun{}
+	err := v.decoder.Decode(req, &run)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	oldPriority := int32(0)
+	if req.Operation == admissionv1.Update {
+		oldRun := CnfCertificationSuiteRun{}
+		err = v.decoder.DecodeRaw(req.OldObject, &oldRun)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldPriority = oldRun.Spec.Priority
+	}
+
+	if run.Spec.Priority == oldPriority {
+		return admission.Allowed("")
+	}
+
+	allowed, err := isUserAllowed(ctx, v.client, &req, &authorizationv1.ResourceAttributes{
+		Namespace:   req.Namespace,
+		Verb:        "update",
+		Group:       GroupVersion.Group,
+		Resource:    "cnfcertificationsuiteruns",
+		Subresource: prioritySubresource,
+		Name:        req.Name,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to review access to CnfCertificationSuiteRun's priority", "user", req.UserInfo.Username)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !allowed {
+		logger.Info("CnfCertificationSuiteRun's priority change denied", "user", req.UserInfo.Username, cnfCertSuiteRunLoggerKey, req.Name)
+		return admission.Denied(fmt.Sprintf("user %q is not allowed to set spec.priority: updating the cnfcertificationsuiteruns/%s "+
+			"subresource is required, e.g. through the priority admin cluster role", req.UserInfo.Username, prioritySubresource))
+	}
+
+	return admission.Allowed("")
+}
+
+// Returns true if the user of the admission request is allowed to perform the action described by
+// the resource attributes.
+func isUserAllowed(ctx context.Context, cl client.Client, req *admission.Request, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               req.UserInfo.Username,
+			Groups:             req.UserInfo.Groups,
+			UID:                req.UserInfo.UID,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+
+	err := cl.Create(ctx, &review)
+	if err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
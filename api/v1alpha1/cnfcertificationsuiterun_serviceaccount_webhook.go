@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+const (
+	serviceAccountWebhookPath = "/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun-serviceaccount"
+	// Verb users must be allowed on a service account in order to run the suite under it.
+	serviceAccountUseVerb = "use"
+)
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun-serviceaccount,mutating=false,failurePolicy=fail,sideEffects=None,groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=create;update,versions=v1alpha1,name=vcnfcertificationsuiterunserviceaccount.kb.io,admissionReviewVersions=v1
+
+// serviceAccountValidator only admits run CRs setting or changing spec.serviceAccountName if the
+// requesting user is allowed to use that service account in the namespace of the run's job pod,
+// so users can't run the suite with permissions they were not granted.
+type serviceAccountValidator struct {
+	client  client.Client
+	decoder admission.Decoder
+}
+
+func newServiceAccountWebhook(cl client.Client, scheme *runtime.Scheme) *webhook.Admission {
+	return &webhook.Admission{Handler: &serviceAccountValidator{client: cl, decoder: admission.NewDecoder(scheme)}}
+}
+
+func (v *serviceAccountValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	run := CnfCertificationSuiteRun{}
+	err := v.decoder.Decode(req, &run)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	oldServiceAccountName := ""
+	if req.Operation == admissionv1.Update {
+		oldRun := CnfCertificationSuiteRun{}
+		err = v.decoder.DecodeRaw(req.OldObject, &oldRun)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldServiceAccountName = oldRun.Spec.ServiceAccountName
+	}
+
+	if run.Spec.ServiceAccountName == "" || run.Spec.ServiceAccountName == oldServiceAccountName {
+		return admission.Allowed("")
+	}
+
+	// The job pods are created in the execution namespace, if set, or in the run CR's one.
+	jobNamespace := os.Getenv(definitions.ExecutionNamespaceEnvVar)
+	if jobNamespace == "" {
+		jobNamespace = req.Namespace
+	}
+
+	allowed, err := isUserAllowed(ctx, v.client, &req, &authorizationv1.ResourceAttributes{
+		Namespace: jobNamespace,
+		Verb:      serviceAccountUseVerb,
+		Resource:  "serviceaccounts",
+		Name:      run.Spec.ServiceAccountName,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to review access to CnfCertificationSuiteRun's service account", "user", req.UserInfo.Username)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !allowed {
+		logger.Info("CnfCertificationSuiteRun's service account denied", "user", req.UserInfo.Username, cnfCertSuiteRunLoggerKey, req.Name,
+			"serviceAccountName", run.Spec.ServiceAccountName)
+		return admission.Denied(fmt.Sprintf("user %q is not allowed to set spec.serviceAccountName: %q on service account %s in namespace %s "+
+			"is required, e.g. through the service account user cluster role", req.UserInfo.Username, serviceAccountUseVerb, run.Spec.ServiceAccountName, jobNamespace))
+	}
+
+	return admission.Allowed("")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,19 +25,26 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // CnfCertificationSuiteRunSpec defines the desired state of CnfCertificationSuiteRun
+// +kubebuilder:validation:XValidation:rule="has(self.configMapName) != has(self.config)",message="exactly one of configMapName and config must be set"
 type CnfCertificationSuiteRunSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// LabelsFilter holds the labels filter/expression of the test cases we want to run.
-	LabelsFilter string `json:"labelsFilter"`
+	// If not set, the operator's run defaults are used, or "all" if they don't have it.
+	LabelsFilter string `json:"labelsFilter,omitempty"`
 	// LogLevel sets the CNF Certification Suite log level (TNF_LOG_LEVEL)
-	LogLevel string `json:"logLevel"`
+	// If not set, the operator's run defaults are used, or "info" if they don't have it.
+	LogLevel string `json:"logLevel,omitempty"`
 
-	// Total timeout for the CNF Cert Suite to run.
-	TimeOut string `json:"timeout"`
+	// Total timeout for the CNF Cert Suite to run, as a duration string, e.g. "1h30m".
+	// If not set, the operator's default timeout is used.
+	TimeOut string `json:"timeout,omitempty"`
 	// ConfigMapName holds the cnf certification suite yaml config.
-	ConfigMapName string `json:"configMapName"`
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Config holds the cnf certification suite config. It can be used instead of ConfigMapName,
+	// so the operator generates the config map.
+	Config *CnfCertSuiteConfig `json:"config,omitempty"`
 	// PreflightSecretName holds the secret name for preflight's dockerconfig.
 	PreflightSecretName *string `json:"preflightSecretName,omitempty"`
 
@@ -46,11 +54,87 @@ type CnfCertificationSuiteRunSpec struct {
 	ShowAllResultsLogs bool `json:"showAllResultsLogs,omitempty"`
 	// ShowCompliantResourcesAlways is set true for showing compliant resources for all ran tcs, and not only of failed tcs.
 	ShowCompliantResourcesAlways bool `json:"showCompliantResourcesAlways,omitempty"`
+	// Priority sets the order of the run in the operator's queue: runs with higher priority start
+	// before the queued runs with lower priority. Setting it requires permission to update the
+	// cnfcertificationsuiteruns/priority subresource.
+	//+kubebuilder:validation:Minimum=-1000
+	//+kubebuilder:validation:Maximum=1000
+	Priority int32 `json:"priority,omitempty"`
+	// CertSuiteImage holds the CNF Certification Suite image to run. If not set, the operator's
+	// run defaults are used, or the operator's built-in image if they don't have it.
+	CertSuiteImage string `json:"certSuiteImage,omitempty"`
+	// ServiceAccountName holds the name of the service account used by the CNF Cert Suite pod.
+	// It must exist in the pod's namespace, and the user creating the run must be allowed to "use"
+	// it there. If empty, the operator's default service account is used.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// ResultsExport sets a collector where the run's report and claim file are pushed once the
+	// results are published.
+	ResultsExport *ResultsExport `json:"resultsExport,omitempty"`
+	// OfflineDB sets an offline certification database, used by the certification checks instead
+	// of the Red Hat catalog APIs, for disconnected clusters.
+	OfflineDB *OfflineDB `json:"offlineDB,omitempty"`
+}
+
+// OfflineDB holds the source of the offline certification database mounted in the CNF Cert Suite
+// container. Exactly one source must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.persistentVolumeClaim) ? 1 : 0) + (has(self.configMap) ? 1 : 0) + (has(self.image) ? 1 : 0) == 1",message="exactly one of persistentVolumeClaim, configMap and image must be set"
+type OfflineDB struct {
+	// PersistentVolumeClaim holds a claim, in the CNF Cert Suite pod's namespace, whose volume has
+	// the database.
+	PersistentVolumeClaim *OfflineDBVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+	// ConfigMap holds a config map, in the run CR's namespace, with the database files.
+	ConfigMap *OfflineDBConfigMap `json:"configMap,omitempty"`
+	// Image holds an image with the database, which is copied to the pod before the CNF Cert
+	// Suite starts.
+	Image *OfflineDBImage `json:"image,omitempty"`
+}
+
+// OfflineDBVolumeClaim holds the persistent volume claim with the offline certification database.
+type OfflineDBVolumeClaim struct {
+	ClaimName string `json:"claimName"`
+	// SubPath holds the database's folder within the volume. Its root is used if not set.
+	SubPath string `json:"subPath,omitempty"`
+}
+
+// OfflineDBConfigMap holds the config map with the offline certification database files.
+type OfflineDBConfigMap struct {
+	Name string `json:"name"`
+	// Items holds the paths of the config map's keys within the database folder, as the database
+	// files are in subfolders. If not set, every key is a file in the database folder's root.
+	Items []corev1.KeyToPath `json:"items,omitempty"`
+}
+
+// OfflineDBImage holds the image with the offline certification database, copied by an init
+// container running the image's cp command. Images without it, like scratch-based images, can't be
+// used: the run fails with an OfflineDBFailed event. OCI artifacts are not supported either, as
+// image volumes are not used: the webhook rejects the ones referenced with a URL scheme, like oci://.
+type OfflineDBImage struct {
+	Image string `json:"image"`
+	// Path holds the database's folder in the image, "/offline-db" by default.
+	//+kubebuilder:default="/offline-db"
+	Path string `json:"path,omitempty"`
+}
+
+// ResultsExport holds the collector where the run's results are pushed, using the certsuite's
+// Collector protocol.
+type ResultsExport struct {
+	// Endpoint holds the URL of the collector, where the results are posted as a multipart form.
+	//+kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// CredentialsSecretName holds the name of a secret in the run CR's namespace with the
+	// collector's credentials, in keys "executedBy", "partnerName" and "password".
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+	// MaxAttempts holds the number of times the results are pushed before giving up.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=10
+	//+kubebuilder:default=3
+	MaxAttempts int `json:"maxAttempts,omitempty"`
 }
 
 type StatusPhase string
 
 const (
+	StatusPhaseCertSuiteQueued      = "CertSuiteQueued"
 	StatusPhaseCertSuiteDeploying   = "CertSuiteDeploying"
 	StatusPhaseCertSuiteDeployError = "CertSuiteDeployError"
 	StatusPhaseCertSuiteRunning     = "CertSuiteRunning"
@@ -63,6 +147,7 @@ const (
 	StatusStateSkipped = "skipped"
 	StatusStateFailed  = "failed"
 	StatusStateError   = "error"
+	StatusStateWaived  = "waived"
 )
 
 const (
@@ -78,12 +163,73 @@ type CnfCertificationSuiteRunStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// Phase holds the current phase of the CNF Certification Suite run.
-	//+kubebuilder:validation:Enum=CertSuiteDeploying;CertSuiteDeployFailure;CertSuiteRunning;CertSuiteFinished;CertSuiteError
+	//+kubebuilder:validation:Enum=CertSuiteQueued;CertSuiteDeploying;CertSuiteDeployError;CertSuiteRunning;CertSuiteFinished;CertSuiteError
 	Phase StatusPhase `json:"phase"`
+	// QueuePosition holds the position of the run in the operator's queue while its phase is
+	// CertSuiteQueued, starting from 1.
+	QueuePosition int `json:"queuePosition,omitempty"`
 	// CnfCertSuitePodName holds the name of the pod where the CNF Certification Suite app is running.
 	CnfCertSuitePodName *string `json:"cnfCertSuitePodName,omitempty"`
+	// Progress holds the progress of the CNF Certification Suite while it's running, updated
+	// periodically by the sidecar from the certsuite's log.
+	Progress *RunProgress `json:"progress,omitempty"`
 	// Report holds the results and information related to the CNF Certification Suite run.
 	Report *CnfCertificationSuiteReport `json:"report,omitempty"`
+	// ArtifactsConfigMap holds the name of the config map, in the run CR's namespace, where the
+	// run's artifacts (e.g. the JUnit XML report) are stored.
+	ArtifactsConfigMap string `json:"artifactsConfigMap,omitempty"`
+	// DroppedArtifacts holds the keys of the run's artifacts that were not stored in the artifacts
+	// config map, as they were too big to fit in it.
+	DroppedArtifacts []string `json:"droppedArtifacts,omitempty"`
+	// CertSuiteLogsTail holds the last lines of the CNF Cert Suite container's output, when the run
+	// didn't finish successfully. Its full output is stored in the artifacts config map.
+	CertSuiteLogsTail string `json:"certSuiteLogsTail,omitempty"`
+	// Notifications holds the delivery status of the notifications sent when the run finished.
+	Notifications []NotificationStatus `json:"notifications,omitempty"`
+	// ResultsExport holds the delivery status of the results pushed to the run's collector.
+	ResultsExport *ResultsExportStatus `json:"resultsExport,omitempty"`
+}
+
+// RunProgress holds the number of test cases run so far, with a tally of their results, and the
+// test case being run.
+type RunProgress struct {
+	// TotalTests holds the number of test cases expected to run, from the labels filter.
+	TotalTests int `json:"totalTests"`
+	// CompletedTests holds the number of test cases whose result was recorded.
+	CompletedTests int `json:"completedTests"`
+	// CurrentTest holds the test case being run, if any.
+	CurrentTest string `json:"currentTest,omitempty"`
+	Passed      int    `json:"passed"`
+	Skipped     int    `json:"skipped"`
+	Failed      int    `json:"failed"`
+	Errored     int    `json:"errored"`
+	// LastUpdateTime holds the time the progress was last updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// NotificationStatus holds the delivery status of a notification to a CnfCertificationNotifier's target.
+type NotificationStatus struct {
+	Notifier string `json:"notifier"`
+	Target   string `json:"target"`
+	// Delivered is set to true if the notification was sent successfully.
+	Delivered bool `json:"delivered"`
+	// Attempts holds the number of times the notification was tried.
+	Attempts int `json:"attempts"`
+	// Error holds the error of the last attempt, if the notification couldn't be delivered.
+	Error string      `json:"error,omitempty"`
+	Time  metav1.Time `json:"time"`
+}
+
+// ResultsExportStatus holds the delivery status of the results pushed to a collector.
+type ResultsExportStatus struct {
+	Endpoint string `json:"endpoint"`
+	// Delivered is set to true if the results were pushed successfully.
+	Delivered bool `json:"delivered"`
+	// Attempts holds the number of times the results were pushed.
+	Attempts int `json:"attempts"`
+	// Error holds the error of the last attempt, if the results couldn't be delivered.
+	Error string      `json:"error,omitempty"`
+	Time  metav1.Time `json:"time"`
 }
 
 type CnfPod struct {
@@ -130,14 +276,42 @@ type TargetResources struct {
 	NonCompliant []TargetResource `json:"nonCompliant,omitempty"`
 }
 
+// TestCaseWaiver holds the CnfCertificationWaiver found for a failed test case.
+type TestCaseWaiver struct {
+	Name           string      `json:"name"`
+	Justification  string      `json:"justification"`
+	Approver       string      `json:"approver"`
+	ExpirationDate metav1.Time `json:"expirationDate"`
+	// Expired is set to true when the waiver has expired, so it was not applied to the test case.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// TestCaseCatalogInfo holds the catalog's information about the best practice checked by a test case.
+type TestCaseCatalogInfo struct {
+	Description           string `json:"description,omitempty"`
+	Remediation           string `json:"remediation,omitempty"`
+	BestPracticeReference string `json:"bestPracticeReference,omitempty"`
+	ExceptionProcess      string `json:"exceptionProcess,omitempty"`
+}
+
 // TestCaseResult holds a test case result
 type TestCaseResult struct {
 	TestCaseName string `json:"testCaseName"`
-	//+kubebuilder:validation:Enum=passed;skipped;failed;error
+	// Suite holds the name of the test suite the test case belongs to.
+	Suite string `json:"suite,omitempty"`
+	// Duration holds the time the test case took to run.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	//+kubebuilder:validation:Enum=passed;skipped;failed;error;waived
 	Result          string           `json:"result"`
 	Reason          string           `json:"reason,omitempty"`
 	Logs            string           `json:"logs,omitempty"`
 	TargetResources *TargetResources `json:"targetResources,omitempty"`
+	Waiver          *TestCaseWaiver  `json:"waiver,omitempty"`
+	// CatalogInfo holds the catalog's information about the failed test cases' best practice.
+	CatalogInfo *TestCaseCatalogInfo `json:"catalogInfo,omitempty"`
+	// RanOffline is set to true for the affiliated-certification checks that used the offline
+	// certification database instead of the Red Hat catalog APIs. The preflight checks don't use it.
+	RanOffline bool `json:"ranOffline,omitempty"`
 }
 
 type CnfCertificationSuiteReportStatusSummary struct {
@@ -146,12 +320,17 @@ type CnfCertificationSuiteReportStatusSummary struct {
 	Skipped int `json:"skipped"`
 	Failed  int `json:"failed"`
 	Errored int `json:"errored"`
+	Waived  int `json:"waived,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.phase",description="CnfCertificationSuiteRun current status"
+//+kubebuilder:printcolumn:name="Queue Position",type="integer",JSONPath=".status.queuePosition",priority=1
+//+kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.progress.completedTests",priority=1
+//+kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.progress.totalTests",priority=1
+//+kubebuilder:printcolumn:name="Current Test",type="string",JSONPath=".status.progress.currentTest",priority=1
 //+kubebuilder:printcolumn:name="Verdict",type="string",JSONPath=".status.report.verdict"
 
 // CnfCertificationSuiteRun is the Schema for the cnfcertificationsuiteruns API
@@ -19,9 +19,15 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"os"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,6 +35,11 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/dockerconfig"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/labels"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
 )
 
 // log is for logging in this package.
@@ -36,27 +47,168 @@ var logger = logf.Log.WithName("cnfcertificationsuiterun-resource")
 
 var c client.Client
 
+// apiReader reads objects directly from the API server, as the operator's namespace may not be
+// in the cache when other namespaces are watched.
+var apiReader client.Reader
+
+const (
+	defaultRunTimeout = time.Hour
+	minRunTimeout     = time.Minute
+	maxRunTimeout     = 24 * time.Hour
+
+	// Set to the run CRs that don't have them, in case the run defaults don't have them either.
+	defaultRunLogLevel     = "info"
+	defaultRunLabelsFilter = catalog.AllLabel
+)
+
+// Timeout settings of the run CRs, that can be overridden through the operator's env vars.
+var (
+	runTimeoutDefault = defaultRunTimeout
+	runTimeoutMin     = minRunTimeout
+	runTimeoutMax     = maxRunTimeout
+)
+
 var (
 	configMapLoggerKey       = "configMapName"
 	preflightSecretLoggerKey = "preflightSecretName"
 	logLevelLoggerKey        = "logLevel"
+	labelsFilterLoggerKey    = "labelsFilter"
 	namespaceLoggerKey       = "ns"
 	cnfCertSuiteRunLoggerKey = "cnfCertificationSuiteRun"
+	timeoutLoggerKey         = "timeout"
+	collectorSecretLoggerKey = "credentialsSecretName"
+	offlineDBImageLoggerKey  = "offlineDBImage"
 )
 
 func (r *CnfCertificationSuiteRun) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	c = mgr.GetClient()
+	apiReader = mgr.GetAPIReader()
+
+	err := loadRunTimeoutSettings()
+	if err != nil {
+		return err
+	}
+
+	mgr.GetWebhookServer().Register(priorityWebhookPath, newPriorityWebhook(mgr.GetClient(), mgr.GetScheme()))
+	mgr.GetWebhookServer().Register(serviceAccountWebhookPath, newServiceAccountWebhook(mgr.GetClient(), mgr.GetScheme()))
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// Returns the duration set in the env var, or the default value if it's not set.
+func getEnvDuration(envVar string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration in env var %s: %w", envVar, err)
+	}
+	return duration, nil
+}
+
+func loadRunTimeoutSettings() error {
+	var err error
+	if runTimeoutMin, err = getEnvDuration(definitions.MinRunTimeoutEnvVar, minRunTimeout); err != nil {
+		return err
+	}
+	if runTimeoutMax, err = getEnvDuration(definitions.MaxRunTimeoutEnvVar, maxRunTimeout); err != nil {
+		return err
+	}
+	if runTimeoutDefault, err = getEnvDuration(definitions.DefaultRunTimeoutEnvVar, defaultRunTimeout); err != nil {
+		return err
+	}
+
+	if runTimeoutMin > runTimeoutMax {
+		return fmt.Errorf("min run timeout (%s) is greater than max run timeout (%s)", runTimeoutMin, runTimeoutMax)
+	}
+	if runTimeoutDefault < runTimeoutMin || runTimeoutDefault > runTimeoutMax {
+		return fmt.Errorf("default run timeout (%s) is out of the allowed range [%s, %s]", runTimeoutDefault, runTimeoutMin, runTimeoutMax)
+	}
+
+	logger.Info("Run timeout settings loaded", "default", runTimeoutDefault, "min", runTimeoutMin, "max", runTimeoutMax)
+	return nil
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/mutate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun,mutating=true,failurePolicy=fail,sideEffects=None,groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=create,versions=v1alpha1,name=mcnfcertificationsuiterun.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &CnfCertificationSuiteRun{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *CnfCertificationSuiteRun) Default() {
+	logger.Info("default", "name", r.Name)
+
+	r.setRunDefaults()
+
+	if r.Spec.TimeOut == "" {
+		r.Spec.TimeOut = runTimeoutDefault.String()
+		logger.Info("CnfCertificationSuiteRun's timeout field set to default", timeoutLoggerKey, r.Spec.TimeOut)
+	}
+
+	if r.Spec.LogLevel == "" {
+		r.Spec.LogLevel = defaultRunLogLevel
+		logger.Info("CnfCertificationSuiteRun's log level field set to default", logLevelLoggerKey, r.Spec.LogLevel)
+	}
+
+	if r.Spec.LabelsFilter == "" {
+		r.Spec.LabelsFilter = defaultRunLabelsFilter
+		logger.Info("CnfCertificationSuiteRun's labels filter field set to default", labelsFilterLoggerKey, r.Spec.LabelsFilter)
+	}
+}
 
-// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //nolint:lll
-//+kubebuilder:webhook:path=/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun,mutating=false,failurePolicy=fail,sideEffects=None,groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=create;update,versions=v1alpha1,name=vcnfcertificationsuiterun.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-cnf-certifications-redhat-com-v1alpha1-cnfcertificationsuiterun,mutating=false,failurePolicy=fail,sideEffects=None,groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=create;update;delete,versions=v1alpha1,name=vcnfcertificationsuiterun.kb.io,admissionReviewVersions=v1
+
+// Returns the run defaults set by the operator's admin in the run defaults config map, if any.
+func getRunDefaults() map[string]string {
+	configMapName := os.Getenv(definitions.RunDefaultsConfigMapEnvVar)
+	controllerNamespace := os.Getenv(definitions.ControllerNamespaceEnvVar)
+	if configMapName == "" || controllerNamespace == "" {
+		return nil
+	}
+
+	configMap := v1.ConfigMap{}
+	err := apiReader.Get(context.TODO(), types.NamespacedName{Name: configMapName, Namespace: controllerNamespace}, &configMap)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get the run defaults config map",
+				configMapLoggerKey, configMapName, namespaceLoggerKey, controllerNamespace)
+		}
+		return nil
+	}
+
+	return configMap.Data
+}
+
+// Sets the fields that were not set by the user to the values of the run defaults config map.
+func (r *CnfCertificationSuiteRun) setRunDefaults() {
+	runDefaults := getRunDefaults()
+	if len(runDefaults) == 0 {
+		return
+	}
+
+	setDefault := func(field *string, key string) {
+		if value := runDefaults[key]; *field == "" && value != "" {
+			*field = value
+			logger.Info("CnfCertificationSuiteRun's field set to default", "field", key, "value", value)
+		}
+	}
+
+	setDefault(&r.Spec.LogLevel, definitions.RunDefaultsLogLevelKey)
+	setDefault(&r.Spec.TimeOut, definitions.RunDefaultsTimeoutKey)
+	setDefault(&r.Spec.LabelsFilter, definitions.RunDefaultsLabelsFilterKey)
+	setDefault(&r.Spec.CertSuiteImage, definitions.RunDefaultsCertSuiteImageKey)
+
+	if preflightSecretName := runDefaults[definitions.RunDefaultsPreflightSecretNameKey]; r.Spec.PreflightSecretName == nil && preflightSecretName != "" {
+		r.Spec.PreflightSecretName = &preflightSecretName
+		logger.Info("CnfCertificationSuiteRun's field set to default", "field", definitions.RunDefaultsPreflightSecretNameKey, "value", preflightSecretName)
+	}
+}
 
 var _ webhook.Validator = &CnfCertificationSuiteRun{}
 
@@ -64,32 +216,84 @@ var _ webhook.Validator = &CnfCertificationSuiteRun{}
 func (r *CnfCertificationSuiteRun) ValidateCreate() (admission.Warnings, error) {
 	logger.Info("validate create", "name", r.Name)
 
-	err := r.validateConfigMap()
+	return r.validateSpec()
+}
+
+func (r *CnfCertificationSuiteRun) validateSpec() (admission.Warnings, error) {
+	config, warnings, err := r.validateConfig()
 	if err != nil {
-		return nil, err
+		return warnings, err
 	}
 
-	err = r.validatePreflightSecret()
+	preflightWarnings, err := r.validatePreflightSecret(config)
 	if err != nil {
-		return nil, err
+		return warnings, err
 	}
+	warnings = append(warnings, preflightWarnings...)
 
 	err = r.validateLogLevel()
 	if err != nil {
-		return nil, err
+		return warnings, err
 	}
 
-	return nil, nil
+	err = r.validateTimeout()
+	if err != nil {
+		return warnings, err
+	}
+
+	labelsWarnings, err := r.validateLabelsFilter()
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, labelsWarnings...)
+
+	err = r.validateResultsExport()
+	if err != nil {
+		return warnings, err
+	}
+
+	offlineDBWarnings, err := r.validateOfflineDB()
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, offlineDBWarnings...)
+
+	return warnings, nil
+}
+
+// Validates the CNF Certification Suite config, either from spec.config or from the config map.
+// Returns the parsed config.
+func (r *CnfCertificationSuiteRun) validateConfig() (*CnfCertSuiteConfig, admission.Warnings, error) {
+	if r.Spec.Config == nil {
+		return r.validateConfigMap()
+	}
+
+	if r.Spec.ConfigMapName != "" {
+		err := fmt.Errorf("spec.configMapName and spec.config can't be set at the same time")
+		logger.Error(err, "CnfCertificationSuiteRun's config is invalid",
+			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
+		return nil, nil, err
+	}
+
+	err := validateCertSuiteConfig(context.TODO(), r.Spec.Config)
+	if err != nil {
+		err = fmt.Errorf("spec.config is invalid: %w", err)
+		logger.Error(err, "CnfCertificationSuiteRun's config is invalid", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
+		return nil, nil, err
+	}
+
+	logger.Info("CnfCertificationSuiteRun's config field is valid", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
+	return r.Spec.Config, nil, nil
 }
 
-func (r *CnfCertificationSuiteRun) validateConfigMap() error {
+func (r *CnfCertificationSuiteRun) validateConfigMap() (*CnfCertSuiteConfig, admission.Warnings, error) {
 	configMap := &v1.ConfigMap{}
 
 	if r.Spec.ConfigMapName == "" {
-		err := fmt.Errorf("spec.configMapName must not be an empty string")
+		err := fmt.Errorf("spec.configMapName must not be an empty string when spec.config is not set")
 		logger.Error(err, "CnfCertificationSuiteRun's config map name is invalid",
 			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
-		return err
+		return nil, nil, err
 	}
 
 	// Return an error if config map is not found by name and ns, or field is empty
@@ -97,28 +301,41 @@ func (r *CnfCertificationSuiteRun) validateConfigMap() error {
 	if err != nil {
 		logger.Error(err, "CnfCertificationSuiteRun's config map name field is invalid",
 			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
-		return err
+		return nil, nil, err
 	}
 
 	// Verify required field exists and that it's not empty
-	if value, exists := configMap.Data["tnf_config.yaml"]; !exists || value == "" {
+	value, exists := configMap.Data["tnf_config.yaml"]
+	if !exists || value == "" {
 		err := fmt.Errorf("config map's 'tnf_config.yaml' field must be set with a non-empty and valid configuration yaml for the CNF Certification Suite")
 		logger.Error(err, "CnfCertificationSuiteRun's config map is invalid",
 			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
-		return err
+		return nil, nil, err
+	}
+
+	// Verify the config's content against the CNF Certification Suite config schema
+	config, warnings, err := parseCertSuiteConfigYaml(value)
+	if err == nil {
+		err = validateCertSuiteConfig(context.TODO(), config)
+	}
+	if err != nil {
+		err = fmt.Errorf("config map %s is invalid: %w", configMap.Name, err)
+		logger.Error(err, "CnfCertificationSuiteRun's config map is invalid",
+			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
+		return nil, warnings, err
 	}
 
 	logger.Info("CnfCertificationSuiteRun's config map field is valid", configMapLoggerKey, configMap.Name, namespaceLoggerKey, r.Namespace)
-	return nil
+	return config, warnings, nil
 }
 
-func (r *CnfCertificationSuiteRun) validatePreflightSecret() error {
+func (r *CnfCertificationSuiteRun) validatePreflightSecret(config *CnfCertSuiteConfig) (admission.Warnings, error) {
 	preflightSecret := &v1.Secret{}
 
 	// Nil Preflight Secret is valid
 	if r.Spec.PreflightSecretName == nil {
 		logger.Info("Warning: No preflight secret was set.", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
-		return nil
+		return nil, nil
 	}
 
 	// Return an error if preflight secret is not found by name and ns, or field is empty
@@ -126,19 +343,66 @@ func (r *CnfCertificationSuiteRun) validatePreflightSecret() error {
 	if err != nil {
 		logger.Error(err, "CnfCertificationSuiteRun's preflight secret name field is invalid",
 			preflightSecretLoggerKey, r.Spec.PreflightSecretName, namespaceLoggerKey, r.Namespace)
-		return err
+		return nil, err
 	}
 
 	// Verify required field exists and that it's not empty
-	if value, exists := preflightSecret.Data["preflight_dockerconfig.json"]; !exists || len(value) == 0 {
-		err := fmt.Errorf("preflight secret's 'preflight_dockerconfig.json' field must be set with a valid docker config json content")
+	secretKey, found := dockerconfig.GetSecretKey(preflightSecret)
+	if !found {
+		err := fmt.Errorf("preflight secret's '%s' or '%s' field must be set with a valid docker config json content",
+			dockerconfig.PreflightSecretKey, v1.DockerConfigJsonKey)
 		logger.Error(err, "CnfCertificationSuiteRun's preflight secret is invalid",
-			configMapLoggerKey, r.Spec.ConfigMapName, namespaceLoggerKey, r.Namespace)
-		return err
+			preflightSecretLoggerKey, r.Spec.PreflightSecretName, namespaceLoggerKey, r.Namespace)
+		return nil, err
+	}
+
+	// Verify the field's content is a docker config json with well formed auths entries
+	dockerConfig, err := dockerconfig.Parse(preflightSecret.Data[secretKey])
+	if err != nil {
+		err = fmt.Errorf("preflight secret's '%s' field is invalid: %w", secretKey, err)
+		logger.Error(err, "CnfCertificationSuiteRun's preflight secret is invalid",
+			preflightSecretLoggerKey, r.Spec.PreflightSecretName, namespaceLoggerKey, r.Namespace)
+		return nil, err
 	}
 
 	logger.Info("CnfCertificationSuiteRun's preflight secret field is valid", preflightSecretLoggerKey, preflightSecret.Name, namespaceLoggerKey, r.Namespace)
-	return nil
+	return getPreflightRegistriesWarnings(dockerConfig, config), nil
+}
+
+// Returns a warning in case none of the registries of the preflight's docker config is used by the
+// images of the pods in the target namespaces, as preflight won't be able to use its credentials.
+func getPreflightRegistriesWarnings(dockerConfig *dockerconfig.Config, config *CnfCertSuiteConfig) admission.Warnings {
+	imagesRegistries := map[string]bool{}
+	for _, targetNamespace := range config.TargetNameSpaces {
+		pods := v1.PodList{}
+		err := apiReader.List(context.TODO(), &pods, client.InNamespace(string(targetNamespace.Name)))
+		if err != nil {
+			logger.Error(err, "Failed to list pods in target namespace", namespaceLoggerKey, targetNamespace.Name)
+			return nil
+		}
+
+		for i := range pods.Items {
+			for _, container := range append(pods.Items[i].Spec.InitContainers, pods.Items[i].Spec.Containers...) {
+				imagesRegistries[dockerconfig.ImageRegistry(container.Image)] = true
+			}
+		}
+	}
+
+	// No pods deployed yet or no credentials set, so there's nothing to compare.
+	if len(imagesRegistries) == 0 || len(dockerConfig.Auths) == 0 {
+		return nil
+	}
+
+	for _, registry := range dockerConfig.Registries() {
+		if imagesRegistries[registry] {
+			return nil
+		}
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("none of the registries in the preflight secret (%s) is used by the images of the pods in the target namespaces",
+			strings.Join(dockerConfig.Registries(), ", ")),
+	}
 }
 
 func (r *CnfCertificationSuiteRun) validateLogLevel() error {
@@ -155,13 +419,141 @@ func (r *CnfCertificationSuiteRun) validateLogLevel() error {
 	return nil
 }
 
+func (r *CnfCertificationSuiteRun) validateTimeout() error {
+	timeout, err := time.ParseDuration(r.Spec.TimeOut)
+	if err != nil {
+		err = fmt.Errorf("spec.timeout %q is not a valid duration (e.g. \"90m\", \"2h\"): %w", r.Spec.TimeOut, err)
+		logger.Error(err, "CnfCertificationSuiteRun's timeout field is invalid", timeoutLoggerKey, r.Spec.TimeOut)
+		return err
+	}
+
+	if timeout < runTimeoutMin || timeout > runTimeoutMax {
+		err = fmt.Errorf("spec.timeout %q is out of the allowed range [%s, %s]", r.Spec.TimeOut, runTimeoutMin, runTimeoutMax)
+		logger.Error(err, "CnfCertificationSuiteRun's timeout field is invalid", timeoutLoggerKey, r.Spec.TimeOut)
+		return err
+	}
+
+	logger.Info("CnfCertificationSuiteRun's timeout field is valid", timeoutLoggerKey, r.Spec.TimeOut)
+	return nil
+}
+
+func (r *CnfCertificationSuiteRun) validateLabelsFilter() (admission.Warnings, error) {
+	evaluator, err := labels.NewEvaluator(r.Spec.LabelsFilter)
+	if err != nil {
+		logger.Error(err, "CnfCertificationSuiteRun's labels filter field is invalid",
+			labelsFilterLoggerKey, r.Spec.LabelsFilter)
+		return nil, err
+	}
+
+	// Labels of test cases not known by this operator's version may still be valid, so only a warning is returned.
+	if len(catalog.MatchingTestCases(evaluator.Eval)) == 0 {
+		logger.Info("Warning: CnfCertificationSuiteRun's labels filter doesn't match any test case",
+			labelsFilterLoggerKey, r.Spec.LabelsFilter)
+		return admission.Warnings{
+			fmt.Sprintf("labels filter %q doesn't match any known test case of the CNF Certification Suite", r.Spec.LabelsFilter),
+		}, nil
+	}
+
+	logger.Info("CnfCertificationSuiteRun's labels filter field is valid", labelsFilterLoggerKey, r.Spec.LabelsFilter)
+	return nil, nil
+}
+
+// Validates that the secret with the collector's credentials exists, if set.
+func (r *CnfCertificationSuiteRun) validateResultsExport() error {
+	if r.Spec.ResultsExport == nil || r.Spec.ResultsExport.CredentialsSecretName == "" {
+		return nil
+	}
+
+	secretName := r.Spec.ResultsExport.CredentialsSecretName
+	err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: r.Namespace}, &v1.Secret{})
+	if err != nil {
+		err = fmt.Errorf("spec.resultsExport.credentialsSecretName %q is invalid: %w", secretName, err)
+		logger.Error(err, "CnfCertificationSuiteRun's results export field is invalid",
+			collectorSecretLoggerKey, secretName, namespaceLoggerKey, r.Namespace)
+		return err
+	}
+
+	logger.Info("CnfCertificationSuiteRun's results export field is valid", collectorSecretLoggerKey, secretName)
+	return nil
+}
+
+// Validates that the offline db image is not an OCI artifact, as the database is copied from the
+// image by an init container running its cp command, and image volumes are not supported. Only the
+// artifacts referenced with a URL scheme, like oci://, can be told apart from container images, so
+// a warning is returned for the others.
+func (r *CnfCertificationSuiteRun) validateOfflineDB() (admission.Warnings, error) {
+	if r.Spec.OfflineDB == nil || r.Spec.OfflineDB.Image == nil {
+		return nil, nil
+	}
+
+	image := r.Spec.OfflineDB.Image.Image
+	if strings.Contains(image, "://") {
+		err := fmt.Errorf("spec.offlineDB.image.image %q is invalid: OCI artifacts are not supported, "+
+			"use a container image with a cp command or a persistent volume claim", image)
+		logger.Error(err, "CnfCertificationSuiteRun's offline db field is invalid", offlineDBImageLoggerKey, image)
+		return nil, err
+	}
+
+	logger.Info("CnfCertificationSuiteRun's offline db field is valid", offlineDBImageLoggerKey, image)
+	return admission.Warnings{fmt.Sprintf("spec.offlineDB.image.image %q must be a container image with a cp command: "+
+		"OCI artifacts are not supported and make the run fail", image)}, nil
+}
+
+// Spec fields that can still be updated once the run has started, as they're only read when the
+// CNF Cert Suite has finished, to build the results.
+var mutableSpecFields = []string{"showAllResultsLogs", "showCompliantResourcesAlways", "resultsExport"}
+
+// Returns the json names of the spec fields whose values differ.
+func getChangedSpecFields(oldSpec, newSpec *CnfCertificationSuiteRunSpec) []string {
+	changedFields := []string{}
+	oldValue := reflect.ValueOf(oldSpec).Elem()
+	newValue := reflect.ValueOf(newSpec).Elem()
+	for i := 0; i < oldValue.NumField(); i++ {
+		if !equality.Semantic.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			jsonName, _, _ := strings.Cut(oldValue.Type().Field(i).Tag.Get("json"), ",")
+			changedFields = append(changedFields, jsonName)
+		}
+	}
+	return changedFields
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-//
-//nolint:revive
 func (r *CnfCertificationSuiteRun) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	logger.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
+	oldRun, ok := old.(*CnfCertificationSuiteRun)
+	if !ok {
+		return nil, fmt.Errorf("expected a CnfCertificationSuiteRun but got a %T", old)
+	}
+
+	changedFields := getChangedSpecFields(&oldRun.Spec, &r.Spec)
+	if len(changedFields) == 0 {
+		return nil, nil
+	}
+
+	// The run hasn't started yet, so the whole spec can still be changed.
+	switch oldRun.Status.Phase {
+	case "", StatusPhaseCertSuiteQueued, StatusPhaseCertSuiteDeploying:
+		return r.validateSpec()
+	}
+
+	immutableFields := []string{}
+	for _, field := range changedFields {
+		if !slices.Contains(mutableSpecFields, field) {
+			immutableFields = append(immutableFields, "spec."+field)
+		}
+	}
+
+	if len(immutableFields) > 0 {
+		err := fmt.Errorf("%s can't be changed: the run has already started (phase %s) and its CNF Certification Suite pod "+
+			"won't be updated. Only spec.%s can be changed at this point. Create a new CnfCertificationSuiteRun to run the "+
+			"CNF Certification Suite with a different spec",
+			strings.Join(immutableFields, ", "), oldRun.Status.Phase, strings.Join(mutableSpecFields, " and spec."))
+		logger.Error(err, "CnfCertificationSuiteRun's update is invalid", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
+		return nil, err
+	}
+
+	logger.Info("CnfCertificationSuiteRun's update is valid", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
 	return nil, nil
 }
 
@@ -169,6 +561,12 @@ func (r *CnfCertificationSuiteRun) ValidateUpdate(old runtime.Object) (admission
 func (r *CnfCertificationSuiteRun) ValidateDelete() (admission.Warnings, error) {
 	logger.Info("validate delete", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object deletion.
+	if r.Annotations[definitions.RunProtectAnnotation] == "true" {
+		err := fmt.Errorf("CnfCertificationSuiteRun %s is protected from deletion by the annotation %s=true. "+
+			"Remove the annotation in order to delete it", r.Name, definitions.RunProtectAnnotation)
+		logger.Error(err, "CnfCertificationSuiteRun's deletion is denied", cnfCertSuiteRunLoggerKey, r.Name, namespaceLoggerKey, r.Namespace)
+		return nil, err
+	}
+
 	return nil, nil
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CnfCertificationWaiverSpec defines an approved exception for a failing test case.
+type CnfCertificationWaiverSpec struct {
+	// TestCaseName holds the id of the waived test case, e.g. "access-control-sys-admin-capability-check".
+	//+kubebuilder:validation:MinLength=1
+	TestCaseName string `json:"testCaseName"`
+	// TargetResources holds the matchers of the non-compliant resources the waiver applies to.
+	// A matcher matches a non-compliant resource when all its keys are found in that resource
+	// with the same values. The test case is only waived if every non-compliant resource is
+	// matched. When empty, the waiver applies to any non-compliant resource.
+	TargetResources []TargetResource `json:"targetResources,omitempty"`
+	// Justification explains why the test case failure is accepted.
+	//+kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+	// Approver holds the name of the person/team that approved the exception.
+	//+kubebuilder:validation:MinLength=1
+	Approver string `json:"approver"`
+	// ExpirationDate is the time after which the waiver is no longer applied.
+	ExpirationDate metav1.Time `json:"expirationDate"`
+}
+
+// IsExpired returns true if the waiver's expiration date is before the given time.
+func (w *CnfCertificationWaiver) IsExpired(now metav1.Time) bool {
+	return w.Spec.ExpirationDate.Before(&now)
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Test Case",type="string",JSONPath=".spec.testCaseName"
+//+kubebuilder:printcolumn:name="Approver",type="string",JSONPath=".spec.approver"
+//+kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".spec.expirationDate"
+
+// CnfCertificationWaiver is the Schema for the cnfcertificationwaivers API
+type CnfCertificationWaiver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CnfCertificationWaiverSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CnfCertificationWaiverList contains a list of CnfCertificationWaiver
+type CnfCertificationWaiverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CnfCertificationWaiver `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CnfCertificationWaiver{}, &CnfCertificationWaiverList{})
+}
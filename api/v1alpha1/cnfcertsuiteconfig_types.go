@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CnfCertSuiteLabel holds a label with the format "key: value", or just "key" to match any value.
+// +kubebuilder:validation:MaxLength=317
+// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?(:\s*([A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?)?)?$`
+type CnfCertSuiteLabel string
+
+// CnfCertSuiteNamespace holds a namespace name.
+// +kubebuilder:validation:MaxLength=63
+// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+type CnfCertSuiteNamespace string
+
+// CnfCertSuiteResourceName holds the name of a kubernetes resource.
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=253
+// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+type CnfCertSuiteResourceName string
+
+type TargetNamespace struct {
+	Name CnfCertSuiteNamespace `json:"name"`
+}
+
+type TargetCrdFilter struct {
+	// NameSuffix holds the suffix of the CRDs names to be tested.
+	//+kubebuilder:validation:MinLength=1
+	NameSuffix string `json:"nameSuffix"`
+	// Scalable is set to true if the CRs of the CRDs can be scaled.
+	Scalable bool `json:"scalable,omitempty"`
+}
+
+type ManagedResource struct {
+	Name CnfCertSuiteResourceName `json:"name"`
+}
+
+type SkipScalingTestResource struct {
+	Name      CnfCertSuiteResourceName `json:"name"`
+	Namespace CnfCertSuiteNamespace    `json:"namespace"`
+}
+
+type SkipHelmChart struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+type AcceptedKernelTaint struct {
+	//+kubebuilder:validation:MinLength=1
+	Module string `json:"module"`
+}
+
+// CnfCertSuiteConfig holds the CNF Certification Suite configuration, as defined in the tnf_config.yaml file.
+type CnfCertSuiteConfig struct {
+	// TargetNameSpaces holds the namespaces where the CNF resources to be tested are deployed.
+	//+kubebuilder:validation:MinItems=1
+	TargetNameSpaces []TargetNamespace `json:"targetNameSpaces"`
+	// PodsUnderTestLabels holds the labels of the pods to be tested.
+	PodsUnderTestLabels []CnfCertSuiteLabel `json:"podsUnderTestLabels,omitempty"`
+	// OperatorsUnderTestLabels holds the labels of the operators to be tested.
+	OperatorsUnderTestLabels []CnfCertSuiteLabel `json:"operatorsUnderTestLabels,omitempty"`
+	// TargetCrdFilters holds the filters of the CRDs to be tested.
+	TargetCrdFilters []TargetCrdFilter `json:"targetCrdFilters,omitempty"`
+	// ManagedDeployments holds the deployments managed by a CR, whose scaling is tested through the CR.
+	ManagedDeployments []ManagedResource `json:"managedDeployments,omitempty"`
+	// ManagedStatefulsets holds the statefulsets managed by a CR, whose scaling is tested through the CR.
+	ManagedStatefulsets []ManagedResource `json:"managedStatefulsets,omitempty"`
+	// AcceptedKernelTaints holds the kernel modules whose taints are accepted.
+	AcceptedKernelTaints []AcceptedKernelTaint `json:"acceptedKernelTaints,omitempty"`
+	// SkipHelmChartList holds the helm charts that are not tested.
+	SkipHelmChartList []SkipHelmChart `json:"skipHelmChartList,omitempty"`
+	// SkipScalingTestDeployments holds the deployments whose scaling is not tested.
+	SkipScalingTestDeployments []SkipScalingTestResource `json:"skipScalingTestDeployments,omitempty"`
+	// SkipScalingTestStatefulsets holds the statefulsets whose scaling is not tested.
+	SkipScalingTestStatefulsets []SkipScalingTestResource `json:"skipScalingTestStatefulsets,omitempty"`
+	// ValidProtocolNames holds extra protocol names allowed in the services' ports.
+	ValidProtocolNames []string `json:"validProtocolNames,omitempty"`
+	// ServicesIgnoreList holds the names of the services that are not tested.
+	ServicesIgnoreList []string `json:"servicesignorelist,omitempty"`
+	// ProbeDaemonSetNamespace holds the namespace where the probe daemonset is deployed.
+	ProbeDaemonSetNamespace CnfCertSuiteNamespace `json:"probeDaemonSetNamespace,omitempty"`
+	// ExecutedBy holds the name of who is running the CNF Certification Suite, for data collection.
+	ExecutedBy string `json:"executedBy,omitempty"`
+	// PartnerName holds the name of the partner owning the CNF, for data collection.
+	PartnerName string `json:"partnerName,omitempty"`
+}
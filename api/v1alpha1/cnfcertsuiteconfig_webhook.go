@@ -0,0 +1,136 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+)
+
+// Fields of the tnf_config.yaml that are no longer used by the CNF Certification Suite, with the
+// hint to be returned in the admission warning.
+var deprecatedCertSuiteConfigFields = map[string]string{
+	"certifiedcontainerinfo":                      "containers' certification status is checked against the images found in the target namespaces",
+	"checkDiscoveredContainerCertificationStatus": "containers' certification status is always checked",
+	"debugDaemonSetNamespace":                     "use probeDaemonSetNamespace instead",
+}
+
+// certSuiteConfigFile holds the fields that can be set in the tnf_config.yaml file of a config map.
+// Besides the ones of the CnfCertSuiteConfig, it accepts the data collection endpoint ones, which
+// are not part of the CR's spec on purpose so credentials are not stored there.
+// +kubebuilder:object:generate=false
+type certSuiteConfigFile struct {
+	CnfCertSuiteConfig   `json:",inline"`
+	CollectorAppEndpoint string      `json:"collectorAppEndpoint,omitempty"`
+	CollectorAppPassword string      `json:"collectorAppPassword,omitempty"`
+	ConnectAPIConfig     interface{} `json:"connectAPIConfig,omitempty"`
+}
+
+// Parses the content of the tnf_config.yaml file against the CNF Certification Suite config schema.
+// Unknown fields are rejected, while deprecated ones are removed and returned as warnings.
+func parseCertSuiteConfigYaml(configYaml string) (*CnfCertSuiteConfig, admission.Warnings, error) {
+	fields := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(configYaml), &fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tnf_config.yaml is not a valid yaml: %w", err)
+	}
+
+	fieldNames := []string{}
+	for field := range fields {
+		fieldNames = append(fieldNames, field)
+	}
+	sort.Strings(fieldNames)
+
+	warnings := admission.Warnings{}
+	for _, field := range fieldNames {
+		if hint, deprecated := deprecatedCertSuiteConfigFields[field]; deprecated {
+			warnings = append(warnings, fmt.Sprintf("tnf_config.yaml field %q is deprecated and will be ignored: %s", field, hint))
+			delete(fields, field)
+		}
+	}
+
+	// Re-encode the remaining fields so they can be checked with a strict decoding.
+	remainingYaml, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to process tnf_config.yaml: %w", err)
+	}
+
+	configFile := certSuiteConfigFile{}
+	err = yaml.UnmarshalStrict(remainingYaml, &configFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tnf_config.yaml doesn't match the CNF Certification Suite config schema: %w", err)
+	}
+
+	return &configFile.CnfCertSuiteConfig, warnings, nil
+}
+
+// Checks the label has the format "key: value", or just "key", with a valid label key and value.
+func validateCertSuiteLabel(field string, label CnfCertSuiteLabel) error {
+	key, value, _ := strings.Cut(string(label), ":")
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	errs := validation.IsQualifiedName(key)
+	errs = append(errs, validation.IsValidLabelValue(value)...)
+	if len(errs) > 0 {
+		return fmt.Errorf("%s has a malformed label %q: %s", field, label, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Checks the CNF Certification Suite config's fields, verifying its labels are well formed and its
+// target namespaces exist.
+func validateCertSuiteConfig(ctx context.Context, config *CnfCertSuiteConfig) error {
+	if len(config.TargetNameSpaces) == 0 {
+		return fmt.Errorf("targetNameSpaces must have at least one namespace")
+	}
+
+	for _, label := range config.PodsUnderTestLabels {
+		if err := validateCertSuiteLabel("podsUnderTestLabels", label); err != nil {
+			return err
+		}
+	}
+
+	for _, label := range config.OperatorsUnderTestLabels {
+		if err := validateCertSuiteLabel("operatorsUnderTestLabels", label); err != nil {
+			return err
+		}
+	}
+
+	for _, targetNamespace := range config.TargetNameSpaces {
+		namespace := v1.Namespace{}
+		err := c.Get(ctx, types.NamespacedName{Name: string(targetNamespace.Name)}, &namespace)
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("target namespace %q doesn't exist", targetNamespace.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get target namespace %q: %w", targetNamespace.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -21,9 +21,191 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceptedKernelTaint) DeepCopyInto(out *AcceptedKernelTaint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceptedKernelTaint.
+func (in *AcceptedKernelTaint) DeepCopy() *AcceptedKernelTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceptedKernelTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertSuiteConfig) DeepCopyInto(out *CnfCertSuiteConfig) {
+	*out = *in
+	if in.TargetNameSpaces != nil {
+		in, out := &in.TargetNameSpaces, &out.TargetNameSpaces
+		*out = make([]TargetNamespace, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodsUnderTestLabels != nil {
+		in, out := &in.PodsUnderTestLabels, &out.PodsUnderTestLabels
+		*out = make([]CnfCertSuiteLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorsUnderTestLabels != nil {
+		in, out := &in.OperatorsUnderTestLabels, &out.OperatorsUnderTestLabels
+		*out = make([]CnfCertSuiteLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetCrdFilters != nil {
+		in, out := &in.TargetCrdFilters, &out.TargetCrdFilters
+		*out = make([]TargetCrdFilter, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedDeployments != nil {
+		in, out := &in.ManagedDeployments, &out.ManagedDeployments
+		*out = make([]ManagedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedStatefulsets != nil {
+		in, out := &in.ManagedStatefulsets, &out.ManagedStatefulsets
+		*out = make([]ManagedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceptedKernelTaints != nil {
+		in, out := &in.AcceptedKernelTaints, &out.AcceptedKernelTaints
+		*out = make([]AcceptedKernelTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipHelmChartList != nil {
+		in, out := &in.SkipHelmChartList, &out.SkipHelmChartList
+		*out = make([]SkipHelmChart, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipScalingTestDeployments != nil {
+		in, out := &in.SkipScalingTestDeployments, &out.SkipScalingTestDeployments
+		*out = make([]SkipScalingTestResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipScalingTestStatefulsets != nil {
+		in, out := &in.SkipScalingTestStatefulsets, &out.SkipScalingTestStatefulsets
+		*out = make([]SkipScalingTestResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidProtocolNames != nil {
+		in, out := &in.ValidProtocolNames, &out.ValidProtocolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServicesIgnoreList != nil {
+		in, out := &in.ServicesIgnoreList, &out.ServicesIgnoreList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertSuiteConfig.
+func (in *CnfCertSuiteConfig) DeepCopy() *CnfCertSuiteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertSuiteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationNotifier) DeepCopyInto(out *CnfCertificationNotifier) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationNotifier.
+func (in *CnfCertificationNotifier) DeepCopy() *CnfCertificationNotifier {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationNotifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CnfCertificationNotifier) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationNotifierList) DeepCopyInto(out *CnfCertificationNotifierList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CnfCertificationNotifier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationNotifierList.
+func (in *CnfCertificationNotifierList) DeepCopy() *CnfCertificationNotifierList {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationNotifierList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CnfCertificationNotifierList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationNotifierSpec) DeepCopyInto(out *CnfCertificationNotifierSpec) {
+	*out = *in
+	if in.RunSelector != nil {
+		in, out := &in.RunSelector, &out.RunSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verdicts != nil {
+		in, out := &in.Verdicts, &out.Verdicts
+		*out = make([]NotifierVerdict, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]NotifierTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationNotifierSpec.
+func (in *CnfCertificationNotifierSpec) DeepCopy() *CnfCertificationNotifierSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationNotifierSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CnfCertificationSuiteReport) DeepCopyInto(out *CnfCertificationSuiteReport) {
 	*out = *in
@@ -125,11 +307,26 @@ func (in *CnfCertificationSuiteRunList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CnfCertificationSuiteRunSpec) DeepCopyInto(out *CnfCertificationSuiteRunSpec) {
 	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(CnfCertSuiteConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PreflightSecretName != nil {
 		in, out := &in.PreflightSecretName, &out.PreflightSecretName
 		*out = new(string)
 		**out = **in
 	}
+	if in.ResultsExport != nil {
+		in, out := &in.ResultsExport, &out.ResultsExport
+		*out = new(ResultsExport)
+		**out = **in
+	}
+	if in.OfflineDB != nil {
+		in, out := &in.OfflineDB, &out.OfflineDB
+		*out = new(OfflineDB)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationSuiteRunSpec.
@@ -150,11 +347,33 @@ func (in *CnfCertificationSuiteRunStatus) DeepCopyInto(out *CnfCertificationSuit
 		*out = new(string)
 		**out = **in
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(RunProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Report != nil {
 		in, out := &in.Report, &out.Report
 		*out = new(CnfCertificationSuiteReport)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DroppedArtifacts != nil {
+		in, out := &in.DroppedArtifacts, &out.DroppedArtifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResultsExport != nil {
+		in, out := &in.ResultsExport, &out.ResultsExport
+		*out = new(ResultsExportStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationSuiteRunStatus.
@@ -167,6 +386,93 @@ func (in *CnfCertificationSuiteRunStatus) DeepCopy() *CnfCertificationSuiteRunSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationWaiver) DeepCopyInto(out *CnfCertificationWaiver) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationWaiver.
+func (in *CnfCertificationWaiver) DeepCopy() *CnfCertificationWaiver {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationWaiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CnfCertificationWaiver) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationWaiverList) DeepCopyInto(out *CnfCertificationWaiverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CnfCertificationWaiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationWaiverList.
+func (in *CnfCertificationWaiverList) DeepCopy() *CnfCertificationWaiverList {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationWaiverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CnfCertificationWaiverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CnfCertificationWaiverSpec) DeepCopyInto(out *CnfCertificationWaiverSpec) {
+	*out = *in
+	if in.TargetResources != nil {
+		in, out := &in.TargetResources, &out.TargetResources
+		*out = make([]TargetResource, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(TargetResource, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+		}
+	}
+	in.ExpirationDate.DeepCopyInto(&out.ExpirationDate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CnfCertificationWaiverSpec.
+func (in *CnfCertificationWaiverSpec) DeepCopy() *CnfCertificationWaiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CnfCertificationWaiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CnfPod) DeepCopyInto(out *CnfPod) {
 	*out = *in
@@ -264,6 +570,271 @@ func (in *CnfTargets) DeepCopy() *CnfTargets {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailTarget) DeepCopyInto(out *EmailTarget) {
+	*out = *in
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailTarget.
+func (in *EmailTarget) DeepCopy() *EmailTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResource) DeepCopyInto(out *ManagedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResource.
+func (in *ManagedResource) DeepCopy() *ManagedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationStatus) DeepCopyInto(out *NotificationStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationStatus.
+func (in *NotificationStatus) DeepCopy() *NotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifierTarget) DeepCopyInto(out *NotifierTarget) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierTarget.
+func (in *NotifierTarget) DeepCopy() *NotifierTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifierTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineDB) DeepCopyInto(out *OfflineDB) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(OfflineDBVolumeClaim)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(OfflineDBConfigMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(OfflineDBImage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineDB.
+func (in *OfflineDB) DeepCopy() *OfflineDB {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineDBConfigMap) DeepCopyInto(out *OfflineDBConfigMap) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineDBConfigMap.
+func (in *OfflineDBConfigMap) DeepCopy() *OfflineDBConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineDBConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineDBImage) DeepCopyInto(out *OfflineDBImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineDBImage.
+func (in *OfflineDBImage) DeepCopy() *OfflineDBImage {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineDBImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineDBVolumeClaim) DeepCopyInto(out *OfflineDBVolumeClaim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineDBVolumeClaim.
+func (in *OfflineDBVolumeClaim) DeepCopy() *OfflineDBVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineDBVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultsExport) DeepCopyInto(out *ResultsExport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultsExport.
+func (in *ResultsExport) DeepCopy() *ResultsExport {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultsExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultsExportStatus) DeepCopyInto(out *ResultsExportStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultsExportStatus.
+func (in *ResultsExportStatus) DeepCopy() *ResultsExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultsExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunProgress) DeepCopyInto(out *RunProgress) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunProgress.
+func (in *RunProgress) DeepCopy() *RunProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RunProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkipHelmChart) DeepCopyInto(out *SkipHelmChart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkipHelmChart.
+func (in *SkipHelmChart) DeepCopy() *SkipHelmChart {
+	if in == nil {
+		return nil
+	}
+	out := new(SkipHelmChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkipScalingTestResource) DeepCopyInto(out *SkipScalingTestResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkipScalingTestResource.
+func (in *SkipScalingTestResource) DeepCopy() *SkipScalingTestResource {
+	if in == nil {
+		return nil
+	}
+	out := new(SkipScalingTestResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetCrdFilter) DeepCopyInto(out *TargetCrdFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetCrdFilter.
+func (in *TargetCrdFilter) DeepCopy() *TargetCrdFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetCrdFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetNamespace) DeepCopyInto(out *TargetNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetNamespace.
+func (in *TargetNamespace) DeepCopy() *TargetNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in TargetResource) DeepCopyInto(out *TargetResource) {
 	{
@@ -326,14 +897,44 @@ func (in *TargetResources) DeepCopy() *TargetResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestCaseCatalogInfo) DeepCopyInto(out *TestCaseCatalogInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCaseCatalogInfo.
+func (in *TestCaseCatalogInfo) DeepCopy() *TestCaseCatalogInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(TestCaseCatalogInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestCaseResult) DeepCopyInto(out *TestCaseResult) {
 	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.TargetResources != nil {
 		in, out := &in.TargetResources, &out.TargetResources
 		*out = new(TargetResources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Waiver != nil {
+		in, out := &in.Waiver, &out.Waiver
+		*out = new(TestCaseWaiver)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CatalogInfo != nil {
+		in, out := &in.CatalogInfo, &out.CatalogInfo
+		*out = new(TestCaseCatalogInfo)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCaseResult.
@@ -345,3 +946,19 @@ func (in *TestCaseResult) DeepCopy() *TestCaseResult {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestCaseWaiver) DeepCopyInto(out *TestCaseWaiver) {
+	*out = *in
+	in.ExpirationDate.DeepCopyInto(&out.ExpirationDate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCaseWaiver.
+func (in *TestCaseWaiver) DeepCopy() *TestCaseWaiver {
+	if in == nil {
+		return nil
+	}
+	out := new(TestCaseWaiver)
+	in.DeepCopyInto(out)
+	return out
+}
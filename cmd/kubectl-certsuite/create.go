@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+type createOptions struct {
+	configMapName       string
+	targetNamespaces    string
+	podLabels           string
+	labelsFilter        string
+	logLevel            string
+	timeout             string
+	preflightSecretName string
+	certSuiteImage      string
+	serviceAccountName  string
+	priority            int
+	showAllResultsLogs  bool
+	showCompliant       bool
+	wait                bool
+}
+
+// Returns the items of a comma separated list.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Returns the run CR built from the create command's options. The cnf certification suite config
+// is either the given config map or a config generated with the target namespaces and pods labels.
+func newRun(namespace, name string, opts *createOptions) (*cnfcertificationsv1alpha1.CnfCertificationSuiteRun, error) {
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: cnfcertificationsv1alpha1.CnfCertificationSuiteRunSpec{
+			LabelsFilter:                 opts.labelsFilter,
+			LogLevel:                     opts.logLevel,
+			TimeOut:                      opts.timeout,
+			CertSuiteImage:               opts.certSuiteImage,
+			ServiceAccountName:           opts.serviceAccountName,
+			Priority:                     int32(opts.priority),
+			ShowAllResultsLogs:           opts.showAllResultsLogs,
+			ShowCompliantResourcesAlways: opts.showCompliant,
+		},
+	}
+	if opts.preflightSecretName != "" {
+		runCR.Spec.PreflightSecretName = &opts.preflightSecretName
+	}
+
+	targetNamespaces := splitList(opts.targetNamespaces)
+	switch {
+	case opts.configMapName != "" && len(targetNamespaces) > 0:
+		return nil, fmt.Errorf("only one of --config-map and --target-namespaces can be set")
+	case opts.configMapName != "":
+		runCR.Spec.ConfigMapName = opts.configMapName
+	case len(targetNamespaces) > 0:
+		config := cnfcertificationsv1alpha1.CnfCertSuiteConfig{}
+		for _, ns := range targetNamespaces {
+			config.TargetNameSpaces = append(config.TargetNameSpaces, cnfcertificationsv1alpha1.TargetNamespace{Name: cnfcertificationsv1alpha1.CnfCertSuiteNamespace(ns)})
+		}
+		for _, label := range splitList(opts.podLabels) {
+			config.PodsUnderTestLabels = append(config.PodsUnderTestLabels, cnfcertificationsv1alpha1.CnfCertSuiteLabel(label))
+		}
+		runCR.Spec.Config = &config
+	default:
+		return nil, fmt.Errorf("one of --config-map and --target-namespaces must be set")
+	}
+
+	if opts.timeout != "" {
+		if _, err := time.ParseDuration(opts.timeout); err != nil {
+			return nil, fmt.Errorf("invalid --timeout %q: %w", opts.timeout, err)
+		}
+	}
+
+	return &runCR, nil
+}
+
+func newCreateCommand(out io.Writer) *command {
+	opts := createOptions{}
+	watchOpts := watchOptions{}
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	flags.StringVar(&opts.configMapName, "config-map", "", "Name of the config map with the cnf certification suite config.")
+	flags.StringVar(&opts.targetNamespaces, "target-namespaces", "", "Comma separated list of target namespaces, to generate the cnf certification suite config instead of using a config map.")
+	flags.StringVar(&opts.podLabels, "pod-labels", "", "Comma separated list of labels of the pods under test, for the generated config.")
+	flags.StringVar(&opts.labelsFilter, "labels-filter", "", "Labels filter of the test cases to run. If not set, the operator's run defaults are used.")
+	flags.StringVar(&opts.logLevel, "log-level", "", "Log level of the cnf certification suite.")
+	flags.StringVar(&opts.timeout, "timeout", "", `Timeout of the run, e.g. "2h".`)
+	flags.StringVar(&opts.preflightSecretName, "preflight-secret", "", "Name of the secret with preflight's dockerconfig.")
+	flags.StringVar(&opts.certSuiteImage, "image", "", "CNF Certification Suite image to run.")
+	flags.StringVar(&opts.serviceAccountName, "service-account", "", "Service account of the cnf certification suite pod.")
+	flags.IntVar(&opts.priority, "priority", 0, "Priority of the run in the operator's queue.")
+	flags.BoolVar(&opts.showAllResultsLogs, "show-all-logs", false, "Show the logs of every test case, and not only of the failed ones.")
+	flags.BoolVar(&opts.showCompliant, "show-compliant", false, "Show the compliant resources of every test case.")
+	flags.BoolVar(&opts.wait, "wait", false, "Follow the run's progress until it finishes.")
+	flags.DurationVar(&watchOpts.interval, "interval", defaultWatchInterval, "Interval between checks of the run's progress, with --wait.")
+
+	return &command{
+		name:        "create",
+		usage:       "NAME (--config-map NAME | --target-namespaces NS[,NS...]) [flags]",
+		description: "Create a CnfCertificationSuiteRun.",
+		flags:       flags,
+		run: func(ctx context.Context, cl client.Client, namespace string, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the run's name must be set")
+			}
+
+			runCR, err := newRun(namespace, args[0], &opts)
+			if err != nil {
+				return err
+			}
+
+			err = cl.Create(ctx, runCR)
+			if err != nil {
+				return fmt.Errorf("failed to create CnfCertificationSuiteRun %s (ns %s): %w", runCR.Name, namespace, err)
+			}
+			fmt.Fprintf(out, "CnfCertificationSuiteRun %s/%s created\n", namespace, runCR.Name)
+
+			if !opts.wait {
+				return nil
+			}
+			return watchRun(ctx, cl, namespace, runCR.Name, &watchOpts, out)
+		},
+	}
+}
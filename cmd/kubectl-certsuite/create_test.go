@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func TestNewRun(t *testing.T) {
+	runCR, err := newRun("cnf-ns", "cnf-run", &createOptions{configMapName: "cnf-config", preflightSecretName: "preflight", timeout: "1h", priority: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, "cnf-run", runCR.Name)
+	assert.Equal(t, "cnf-ns", runCR.Namespace)
+	assert.Equal(t, "cnf-config", runCR.Spec.ConfigMapName)
+	assert.Nil(t, runCR.Spec.Config)
+	assert.Equal(t, "preflight", *runCR.Spec.PreflightSecretName)
+	assert.Equal(t, int32(10), runCR.Spec.Priority)
+
+	runCR, err = newRun("cnf-ns", "cnf-run", &createOptions{targetNamespaces: "tnf, tnf2", podLabels: "app=cnf"})
+	assert.Nil(t, err)
+	assert.Empty(t, runCR.Spec.ConfigMapName)
+	assert.Nil(t, runCR.Spec.PreflightSecretName)
+	assert.Equal(t, []cnfcertificationsv1alpha1.TargetNamespace{{Name: "tnf"}, {Name: "tnf2"}}, runCR.Spec.Config.TargetNameSpaces)
+	assert.Equal(t, []cnfcertificationsv1alpha1.CnfCertSuiteLabel{"app=cnf"}, runCR.Spec.Config.PodsUnderTestLabels)
+
+	_, err = newRun("cnf-ns", "cnf-run", &createOptions{})
+	assert.NotNil(t, err)
+	_, err = newRun("cnf-ns", "cnf-run", &createOptions{configMapName: "cnf-config", targetNamespaces: "tnf"})
+	assert.NotNil(t, err)
+	_, err = newRun("cnf-ns", "cnf-run", &createOptions{configMapName: "cnf-config", timeout: "2 hours"})
+	assert.NotNil(t, err)
+}
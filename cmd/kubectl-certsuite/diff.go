@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+// Result shown for the test cases missing in one of the diffed runs.
+const missingResult = "-"
+
+// resultDiff holds a test case whose result differs between two runs.
+type resultDiff struct {
+	testCaseName string
+	suite        string
+	oldResult    string
+	newResult    string
+}
+
+func getResultsByTestCase(report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) map[string]*cnfcertificationsv1alpha1.TestCaseResult {
+	results := map[string]*cnfcertificationsv1alpha1.TestCaseResult{}
+	for i := range report.Results {
+		results[report.Results[i].TestCaseName] = &report.Results[i]
+	}
+	return results
+}
+
+// Returns the test cases whose result differs between the old and the new report, including the
+// ones that are only in one of them, sorted by suite and test case.
+func diffReports(oldReport, newReport *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) []resultDiff {
+	oldResults := getResultsByTestCase(oldReport)
+	newResults := getResultsByTestCase(newReport)
+
+	diffs := []resultDiff{}
+	for name, oldResult := range oldResults {
+		diff := resultDiff{testCaseName: name, suite: getSuite(oldResult), oldResult: oldResult.Result, newResult: missingResult}
+		if newResult, found := newResults[name]; found {
+			diff.newResult = newResult.Result
+		}
+		if diff.oldResult != diff.newResult {
+			diffs = append(diffs, diff)
+		}
+	}
+	for name, newResult := range newResults {
+		if _, found := oldResults[name]; !found {
+			diffs = append(diffs, resultDiff{testCaseName: name, suite: getSuite(newResult), oldResult: missingResult, newResult: newResult.Result})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].suite != diffs[j].suite {
+			return diffs[i].suite < diffs[j].suite
+		}
+		return diffs[i].testCaseName < diffs[j].testCaseName
+	})
+	return diffs
+}
+
+func printDiff(out io.Writer, oldName, newName string, oldReport, newReport *cnfcertificationsv1alpha1.CnfCertificationSuiteReport, diffs []resultDiff) {
+	fmt.Fprintf(out, "Verdict: %s -> %s\n", oldReport.Verdict, newReport.Verdict)
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "No test case results changed.")
+		return
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "SUITE\tTEST CASE\t%s\t%s\n", oldName, newName)
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", diff.suite, diff.testCaseName, diff.oldResult, diff.newResult)
+	}
+	w.Flush()
+}
+
+func newDiffCommand(out io.Writer) *command {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+
+	return &command{
+		name:        "diff",
+		usage:       "OLD-NAME NEW-NAME [flags]",
+		description: "Print the test cases whose result changed between two CnfCertificationSuiteRuns.",
+		flags:       flags,
+		run: func(ctx context.Context, cl client.Client, namespace string, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("the names of the two runs must be set")
+			}
+
+			oldReport, err := getRunReport(ctx, cl, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			newReport, err := getRunReport(ctx, cl, namespace, args[1])
+			if err != nil {
+				return err
+			}
+
+			printDiff(out, args[0], args[1], oldReport, newReport, diffReports(oldReport, newReport))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func TestDiffReports(t *testing.T) {
+	oldReport := newReport()
+	newReport := newReport()
+	newReport.Verdict = cnfcertificationsv1alpha1.StatusVerdictPass
+	newReport.Results[0].Result = cnfcertificationsv1alpha1.StatusStatePassed
+	newReport.Results = append(newReport.Results[:2], cnfcertificationsv1alpha1.TestCaseResult{
+		TestCaseName: "networking-icmpv4-connectivity", Suite: "networking", Result: cnfcertificationsv1alpha1.StatusStatePassed,
+	})
+
+	diffs := diffReports(oldReport, newReport)
+	assert.Equal(t, []resultDiff{
+		{testCaseName: "networking-icmpv4-connectivity", suite: "networking", oldResult: missingResult, newResult: "passed"},
+		{testCaseName: "observability-pod-disruption-budget", suite: "observability", oldResult: "failed", newResult: "passed"},
+		{testCaseName: "operator-install-source", suite: "operator", oldResult: "skipped", newResult: missingResult},
+	}, diffs)
+
+	out := bytes.Buffer{}
+	printDiff(&out, "run-1", "run-2", oldReport, newReport, diffs)
+	assert.Equal(t, `Verdict: fail -> pass
+
+SUITE          TEST CASE                            run-1    run-2
+networking     networking-icmpv4-connectivity       -        passed
+observability  observability-pod-disruption-budget  failed   passed
+operator       operator-install-source              skipped  -
+`, out.String())
+
+	out.Reset()
+	printDiff(&out, "run-1", "run-1", oldReport, oldReport, diffReports(oldReport, oldReport))
+	assert.Equal(t, "Verdict: fail -> fail\nNo test case results changed.\n", out.String())
+}
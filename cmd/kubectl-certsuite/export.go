@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/junit"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/render"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/sarif"
+)
+
+type exportOptions struct {
+	format string
+	output string
+}
+
+// Exporters of the report, by format.
+var exporters = map[string]func(namespace, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error){
+	"json": func(_, _ string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+		out, err := json.MarshalIndent(report, "", "  ")
+		return append(out, '\n'), err
+	},
+	"junit": func(_, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+		return junit.Export(name, report)
+	},
+	"sarif":    sarif.Export,
+	"html":     render.HTML,
+	"markdown": render.Markdown,
+}
+
+func newExportCommand(out io.Writer) *command {
+	opts := exportOptions{}
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&opts.format, "format", "json", "Format of the exported report: json, junit, sarif, html or markdown.")
+	flags.StringVar(&opts.output, "o", "", "File to write the report to. If not set, it's written to the standard output.")
+
+	return &command{
+		name:        "export",
+		usage:       "NAME [flags]",
+		description: "Export the report of a CnfCertificationSuiteRun as JSON, JUnit XML, SARIF, HTML or Markdown.",
+		flags:       flags,
+		run: func(ctx context.Context, cl client.Client, namespace string, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the run's name must be set")
+			}
+
+			export, found := exporters[opts.format]
+			if !found {
+				return fmt.Errorf("unknown format %q", opts.format)
+			}
+
+			report, err := getRunReport(ctx, cl, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := export(namespace, args[0], report)
+			if err != nil {
+				return err
+			}
+
+			if opts.output == "" {
+				_, err = out.Write(data)
+				return err
+			}
+			return os.WriteFile(opts.output, data, 0o600)
+		},
+	}
+}
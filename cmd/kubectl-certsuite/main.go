@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-certsuite is a kubectl/oc plugin to create CnfCertificationSuiteRun CRs, follow their
+// progress and read their reports. Once in the PATH, it's run with "kubectl certsuite <command>".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cnfcertificationsv1alpha1.AddToScheme(scheme))
+}
+
+// command is a subcommand of the plugin. Its run function gets the arguments left after parsing
+// the command's flags.
+type command struct {
+	name        string
+	usage       string
+	description string
+	flags       *flag.FlagSet
+	run         func(ctx context.Context, cl client.Client, namespace string, args []string) error
+}
+
+// Flags shared by every command.
+type commonFlags struct {
+	kubeconfig string
+	namespace  string
+}
+
+func (f *commonFlags) addTo(flags *flag.FlagSet) {
+	flags.StringVar(&f.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file. If not set, the default loading rules are used.")
+	flags.StringVar(&f.namespace, "namespace", "", "Namespace of the runs. If not set, the kubeconfig context's namespace is used.")
+	flags.StringVar(&f.namespace, "n", "", "Shorthand for --namespace.")
+}
+
+// Returns a client for the kubeconfig's cluster and the namespace to be used.
+func (f *commonFlags) newClient() (client.Client, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = f.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	namespace := f.namespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get the kubeconfig's namespace: %w", err)
+		}
+	}
+
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create client: %w", err)
+	}
+	return cl, namespace, nil
+}
+
+// Returns the run CR with the given name.
+func getRun(ctx context.Context, cl client.Client, namespace, name string) (*cnfcertificationsv1alpha1.CnfCertificationSuiteRun, error) {
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &runCR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CnfCertificationSuiteRun %s (ns %s): %w", name, namespace, err)
+	}
+	return &runCR, nil
+}
+
+// Returns the report of the run CR with the given name, or an error if it doesn't have it yet.
+func getRunReport(ctx context.Context, cl client.Client, namespace, name string) (*cnfcertificationsv1alpha1.CnfCertificationSuiteReport, error) {
+	runCR, err := getRun(ctx, cl, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if runCR.Status.Report == nil {
+		return nil, fmt.Errorf("CnfCertificationSuiteRun %s has no report yet (phase %s)", name, runCR.Status.Phase)
+	}
+	return runCR.Status.Report, nil
+}
+
+func printUsage(w io.Writer, commands []*command) {
+	fmt.Fprintf(w, "Usage: kubectl certsuite <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(w, "\nRun \"kubectl certsuite <command> -h\" for the command's flags.\n")
+}
+
+func newCommands(common *commonFlags, out io.Writer) []*command {
+	commands := []*command{
+		newCreateCommand(out),
+		newWatchCommand(out),
+		newResultsCommand(out),
+		newDiffCommand(out),
+		newExportCommand(out),
+	}
+	for _, cmd := range commands {
+		common.addTo(cmd.flags)
+		cmd.flags.Usage = func() {
+			fmt.Fprintf(cmd.flags.Output(), "Usage: kubectl certsuite %s %s\n\n%s\n\nFlags:\n", cmd.name, cmd.usage, cmd.description)
+			cmd.flags.PrintDefaults()
+		}
+	}
+	return commands
+}
+
+func main() {
+	common := commonFlags{}
+	commands := newCommands(&common, os.Stdout)
+
+	if len(os.Args) < 2 {
+		printUsage(os.Stderr, commands)
+		os.Exit(1)
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		printUsage(os.Stderr, commands)
+		os.Exit(1)
+	}
+
+	// Parse the flags set before and after the positional arguments.
+	args := []string{}
+	remaining := os.Args[2:]
+	for {
+		_ = cmd.flags.Parse(remaining)
+		remaining = cmd.flags.Args()
+		if len(remaining) == 0 {
+			break
+		}
+		args = append(args, remaining[0])
+		remaining = remaining[1:]
+	}
+
+	cl, namespace, err := common.newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = cmd.run(context.Background(), cl, namespace, args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
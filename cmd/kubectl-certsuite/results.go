@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+)
+
+// ANSI colors of the test cases' results. They all have the same length, so the table's columns
+// stay aligned.
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorGray   = "\033[90m"
+)
+
+var resultColors = map[string]string{
+	cnfcertificationsv1alpha1.StatusStatePassed:  colorGreen,
+	cnfcertificationsv1alpha1.StatusStateSkipped: colorGray,
+	cnfcertificationsv1alpha1.StatusStateFailed:  colorRed,
+	cnfcertificationsv1alpha1.StatusStateError:   colorRed,
+	cnfcertificationsv1alpha1.StatusStateWaived:  colorYellow,
+}
+
+type resultsOptions struct {
+	states  string
+	suites  string
+	noColor bool
+}
+
+// Returns the suite of the test case result, from the catalog for the reports that don't have it.
+func getSuite(result *cnfcertificationsv1alpha1.TestCaseResult) string {
+	if result.Suite != "" {
+		return result.Suite
+	}
+	if tc, found := catalog.Get(result.TestCaseName); found {
+		return tc.Suite
+	}
+	return ""
+}
+
+// Returns the results with any of the given states and suites, sorted by suite and test case.
+// Empty lists match every result.
+func filterResults(results []cnfcertificationsv1alpha1.TestCaseResult, states, suites []string) []cnfcertificationsv1alpha1.TestCaseResult {
+	filtered := []cnfcertificationsv1alpha1.TestCaseResult{}
+	for i := range results {
+		result := results[i]
+		result.Suite = getSuite(&result)
+		if len(states) > 0 && !slices.Contains(states, result.Result) {
+			continue
+		}
+		if len(suites) > 0 && !slices.Contains(suites, result.Suite) {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Suite != filtered[j].Suite {
+			return filtered[i].Suite < filtered[j].Suite
+		}
+		return filtered[i].TestCaseName < filtered[j].TestCaseName
+	})
+	return filtered
+}
+
+func colorize(text, color string, enabled bool) string {
+	if !enabled || color == "" {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// Prints the report's verdict and summary, and the results as a table.
+func printResults(out io.Writer, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport, results []cnfcertificationsv1alpha1.TestCaseResult, color bool) {
+	verdictColor := colorGreen
+	switch report.Verdict {
+	case cnfcertificationsv1alpha1.StatusVerdictFail, cnfcertificationsv1alpha1.StatusVerdictError:
+		verdictColor = colorRed
+	case cnfcertificationsv1alpha1.StatusVerdictSkip:
+		verdictColor = colorGray
+	}
+
+	summary := &report.Summary
+	fmt.Fprintf(out, "Verdict: %s\n", colorize(report.Verdict, verdictColor, color))
+	fmt.Fprintf(out, "Summary: %d total, %d passed, %d skipped, %d failed, %d errored, %d waived\n\n",
+		summary.Total, summary.Passed, summary.Skipped, summary.Failed, summary.Errored, summary.Waived)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SUITE\tTEST CASE\tRESULT\tREASON")
+	for i := range results {
+		result := &results[i]
+		reason := strings.Join(strings.Fields(result.Reason), " ")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Suite, result.TestCaseName, colorize(result.Result, resultColors[result.Result], color), reason)
+	}
+	w.Flush()
+}
+
+// Returns true if the file is a terminal, so colors can be used.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func newResultsCommand(out io.Writer) *command {
+	opts := resultsOptions{}
+	flags := flag.NewFlagSet("results", flag.ExitOnError)
+	flags.StringVar(&opts.states, "state", "", "Comma separated list of results to show: passed, skipped, failed, error, waived.")
+	flags.StringVar(&opts.suites, "suite", "", "Comma separated list of suites to show.")
+	flags.BoolVar(&opts.noColor, "no-color", false, "Don't color the results.")
+
+	return &command{
+		name:        "results",
+		usage:       "NAME [flags]",
+		description: "Print the summary and the test case results of a CnfCertificationSuiteRun.",
+		flags:       flags,
+		run: func(ctx context.Context, cl client.Client, namespace string, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the run's name must be set")
+			}
+
+			report, err := getRunReport(ctx, cl, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			results := filterResults(report.Results, splitList(opts.states), splitList(opts.suites))
+			printResults(out, report, results, !opts.noColor && out == os.Stdout && isTerminal(os.Stdout))
+			return nil
+		},
+	}
+}
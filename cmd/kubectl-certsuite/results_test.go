@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func newReport() *cnfcertificationsv1alpha1.CnfCertificationSuiteReport {
+	return &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Verdict: cnfcertificationsv1alpha1.StatusVerdictFail,
+		Summary: cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary{Total: 3, Passed: 1, Skipped: 1, Failed: 1},
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{TestCaseName: "observability-pod-disruption-budget", Suite: "observability", Result: cnfcertificationsv1alpha1.StatusStateFailed, Reason: "no\nPDB"},
+			{TestCaseName: "observability-crd-status", Suite: "observability", Result: cnfcertificationsv1alpha1.StatusStatePassed},
+			// The suite is taken from the catalog.
+			{TestCaseName: "operator-install-source", Result: cnfcertificationsv1alpha1.StatusStateSkipped, Reason: "no matching labels"},
+		},
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	results := filterResults(newReport().Results, nil, nil)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "observability-crd-status", results[0].TestCaseName)
+	assert.Equal(t, "operator", results[2].Suite)
+
+	results = filterResults(newReport().Results, []string{"failed", "skipped"}, nil)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "observability-pod-disruption-budget", results[0].TestCaseName)
+
+	results = filterResults(newReport().Results, []string{"failed", "skipped"}, []string{"operator"})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "operator-install-source", results[0].TestCaseName)
+}
+
+func TestPrintResults(t *testing.T) {
+	report := newReport()
+	out := bytes.Buffer{}
+	printResults(&out, report, filterResults(report.Results, []string{"failed", "skipped"}, nil), false)
+	assert.Equal(t, `Verdict: fail
+Summary: 3 total, 1 passed, 1 skipped, 1 failed, 0 errored, 0 waived
+
+SUITE          TEST CASE                            RESULT   REASON
+observability  observability-pod-disruption-budget  failed   no PDB
+operator       operator-install-source              skipped  no matching labels
+`, out.String())
+
+	out.Reset()
+	printResults(&out, report, filterResults(report.Results, []string{"failed"}, nil), true)
+	assert.Contains(t, out.String(), "Verdict: "+colorRed+"fail"+colorReset)
+	assert.Contains(t, out.String(), colorRed+"failed"+colorReset)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+type watchOptions struct {
+	interval time.Duration
+}
+
+// Returns true if the run can't progress anymore.
+func isFinalPhase(phase cnfcertificationsv1alpha1.StatusPhase) bool {
+	switch phase {
+	case cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError:
+		return true
+	}
+	return false
+}
+
+// Returns the line describing the run's progress.
+func getProgressLine(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) string {
+	status := &runCR.Status
+	line := fmt.Sprintf("phase: %s", status.Phase)
+	if status.Phase == cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued && status.QueuePosition > 0 {
+		line += fmt.Sprintf(", queue position: %d", status.QueuePosition)
+	}
+	if status.CnfCertSuitePodName != nil {
+		line += fmt.Sprintf(", pod: %s", *status.CnfCertSuitePodName)
+	}
+	if progress := status.Progress; progress != nil && status.Report == nil {
+		line += fmt.Sprintf(", progress: %d/%d (%d passed, %d skipped, %d failed, %d errored)",
+			progress.CompletedTests, progress.TotalTests, progress.Passed, progress.Skipped, progress.Failed, progress.Errored)
+		if progress.CurrentTest != "" {
+			line += fmt.Sprintf(", running: %s", progress.CurrentTest)
+		}
+	}
+	if status.Report != nil {
+		line += fmt.Sprintf(", verdict: %s", status.Report.Verdict)
+	}
+	return line
+}
+
+// Prints the run's progress every time it changes, until it reaches a final phase. Returns an
+// error if the run failed.
+func watchRun(ctx context.Context, cl client.Client, namespace, name string, opts *watchOptions, out io.Writer) error {
+	lastLine := ""
+	for {
+		runCR, err := getRun(ctx, cl, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		line := getProgressLine(runCR)
+		if line != lastLine {
+			fmt.Fprintf(out, "%s %s\n", time.Now().Format(time.TimeOnly), line)
+			lastLine = line
+		}
+
+		if isFinalPhase(runCR.Status.Phase) {
+			if runCR.Status.Phase != cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished {
+				return fmt.Errorf("CnfCertificationSuiteRun %s finished with phase %s", name, runCR.Status.Phase)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+func newWatchCommand(out io.Writer) *command {
+	opts := watchOptions{}
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	flags.DurationVar(&opts.interval, "interval", defaultWatchInterval, "Interval between checks of the run's progress.")
+
+	return &command{
+		name:        "watch",
+		usage:       "NAME [flags]",
+		description: "Follow the progress of a CnfCertificationSuiteRun until it finishes.",
+		flags:       flags,
+		run: func(ctx context.Context, cl client.Client, namespace string, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the run's name must be set")
+			}
+			return watchRun(ctx, cl, namespace, args[0], &opts, out)
+		},
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -28,6 +29,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -38,6 +40,7 @@ import (
 	consolev1 "github.com/openshift/api/console/v1"
 	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	//+kubebuilder:scaffold:imports
 )
@@ -56,18 +59,45 @@ func init() {
 	//+kubebuilder:scaffold:scheme
 }
 
-// getWatchNamespace returns the Namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
+// getWatchNamespaces returns the Namespaces the operator should be watching for changes
+func getWatchNamespaces() ([]string, error) {
 	// WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
-	// which specifies the Namespace to watch.
+	// which specifies the comma-separated list of Namespaces to watch.
 	// An empty value means the operator is running with cluster scope.
 	var watchNamespaceEnvVar = "WATCH_NAMESPACE"
 
 	ns, found := os.LookupEnv(watchNamespaceEnvVar)
 	if !found {
-		return "", fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+		return nil, fmt.Errorf("%s must be set", watchNamespaceEnvVar)
 	}
-	return ns, nil
+
+	namespaces := []string{}
+	for _, namespace := range strings.Split(ns, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// getCacheNamespaces returns the cache config for the watched namespaces. The job pods' execution
+// namespace, if set, is added to them so the controller can follow its pods. A nil map is
+// returned when no namespace is watched, so the cache works with cluster scope.
+func getCacheNamespaces(watchNamespaces []string) map[string]cache.Config {
+	if len(watchNamespaces) == 0 {
+		return nil
+	}
+
+	namespaces := map[string]cache.Config{}
+	for _, namespace := range watchNamespaces {
+		namespaces[namespace] = cache.Config{}
+	}
+
+	if executionNamespace := os.Getenv(definitions.ExecutionNamespaceEnvVar); executionNamespace != "" {
+		namespaces[executionNamespace] = cache.Config{}
+	}
+	return namespaces
 }
 
 //nolint:funlen
@@ -94,11 +124,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	watchNamespace, err := getWatchNamespace()
+	watchNamespaces, err := getWatchNamespaces()
 	if err != nil {
 		setupLog.Error(err, "unable to get WatchNamespace, "+
 			"the manager will watch and manage resources in all namespaces")
 	}
+	setupLog.Info("watching namespaces", "namespaces", watchNamespaces)
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -143,9 +174,7 @@ func main() {
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				watchNamespace: {},
-			},
+			DefaultNamespaces: getCacheNamespaces(watchNamespaces),
 		},
 	})
 	if err != nil {
@@ -154,8 +183,11 @@ func main() {
 	}
 
 	if err = (&controller.CnfCertificationSuiteRunReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		APIReader:  mgr.GetAPIReader(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("cnf-certsuite-controller"),
+		KubeClient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CnfCertificationSuiteRun")
 		os.Exit(1)
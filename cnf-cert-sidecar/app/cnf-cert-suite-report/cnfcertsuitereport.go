@@ -2,13 +2,19 @@ package cnfcertsuitereport
 
 import (
 	"encoding/json"
+	"time"
 
 	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/claim"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Suite whose test cases check the certification status of the CNF's images, operators and helm
+// charts in the Red Hat catalog, or in the offline certification database if the run has it.
+const certificationSuite = "affiliated-certification"
+
 type Config struct {
 	ReportCrName           string
 	Namespace              string
@@ -95,16 +101,29 @@ func New(config *Config) *cnfcertificationsv1alpha1.CnfCertificationSuiteReport
 	}
 }
 
-func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, claimSchema *claim.Schema) {
+// Sets the run CR's report from the claim's content. Failed test cases covered by a non-expired
+// waiver are set as waived, and are not taken into account for the verdict.
+//
+//nolint:funlen
+func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, claimSchema *claim.Schema, waivers []cnfcertificationsv1alpha1.CnfCertificationWaiver) {
 	testSuiteResults := &claimSchema.Claim.Results
 	results := []cnfcertificationsv1alpha1.TestCaseResult{}
-	totalTests, passedTests, skippedTests, failedTests, erroredTests := 0, 0, 0, 0, 0
+	totalTests, passedTests, skippedTests, failedTests, erroredTests, waivedTests := 0, 0, 0, 0, 0, 0
+	now := metav1.Now()
 	for tcName := range *testSuiteResults {
 		tcResult := (*testSuiteResults)[tcName]
 		testCaseResult := cnfcertificationsv1alpha1.TestCaseResult{
 			TestCaseName: tcName,
+			Suite:        tcResult.TestID.Suite,
 			Result:       tcResult.State,
 		}
+		if runCR.Spec.OfflineDB != nil && tcResult.TestID.Suite == certificationSuite && tcResult.State != cnfcertificationsv1alpha1.StatusStateSkipped {
+			testCaseResult.RanOffline = true
+		}
+		if tcResult.Duration > 0 {
+			// The claim holds the test cases' duration in seconds.
+			testCaseResult.Duration = &metav1.Duration{Duration: time.Duration(tcResult.Duration) * time.Second}
+		}
 
 		switch tcResult.State {
 		case cnfcertificationsv1alpha1.StatusStatePassed:
@@ -116,6 +135,12 @@ func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, c
 			failedTests++
 			testCaseResult.Reason = tcResult.FailureReason
 			testCaseResult.Logs = tcResult.CapturedTestOutput
+			testCaseResult.CatalogInfo = &cnfcertificationsv1alpha1.TestCaseCatalogInfo{
+				Description:           tcResult.CatalogInfo.Description,
+				Remediation:           tcResult.CatalogInfo.Remediation,
+				BestPracticeReference: tcResult.CatalogInfo.BestPracticeReference,
+				ExceptionProcess:      tcResult.CatalogInfo.ExceptionProcess,
+			}
 		case cnfcertificationsv1alpha1.StatusStateError:
 			erroredTests++
 		}
@@ -128,6 +153,10 @@ func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, c
 		if runCR.Spec.ShowAllResultsLogs {
 			testCaseResult.Logs = tcResult.CapturedTestOutput
 		}
+		if tcResult.State == cnfcertificationsv1alpha1.StatusStateFailed && applyWaivers(&testCaseResult, waivers, now) {
+			failedTests--
+			waivedTests++
+		}
 		totalTests++
 		results = append(results, testCaseResult)
 	}
@@ -143,6 +172,7 @@ func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, c
 			Skipped: skippedTests,
 			Failed:  failedTests,
 			Errored: erroredTests,
+			Waived:  waivedTests,
 		},
 	}
 
@@ -151,7 +181,7 @@ func SetRunCRStatus(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, c
 		runCR.Status.Report.Verdict = cnfcertificationsv1alpha1.StatusVerdictError
 	case failedTests >= 1: // at least one failed test
 		runCR.Status.Report.Verdict = cnfcertificationsv1alpha1.StatusVerdictFail
-	case skippedTests == totalTests: // all tests were skipped
+	case totalTests-waivedTests > 0 && skippedTests == totalTests-waivedTests: // all the non-waived tests were skipped
 		runCR.Status.Report.Verdict = cnfcertificationsv1alpha1.StatusVerdictSkip
 	default: // all tests who ran have passed
 		runCR.Status.Report.Verdict = cnfcertificationsv1alpha1.StatusVerdictPass
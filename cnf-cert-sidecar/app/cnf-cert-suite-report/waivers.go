@@ -0,0 +1,89 @@
+package cnfcertsuitereport
+
+import (
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Returns true if all the key/values of the matcher are found in the target resource.
+func matchesTargetResource(matcher, resource cnfcertificationsv1alpha1.TargetResource) bool {
+	for key, value := range matcher {
+		if resourceValue, found := resource[key]; !found || resourceValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the waiver applies to the test case. If the waiver has target resource matchers,
+// every non-compliant resource of the test case must be matched by at least one of them.
+func waiverCoversTestCase(waiver *cnfcertificationsv1alpha1.CnfCertificationWaiver, testCaseResult *cnfcertificationsv1alpha1.TestCaseResult) bool {
+	if waiver.Spec.TestCaseName != testCaseResult.TestCaseName {
+		return false
+	}
+
+	if len(waiver.Spec.TargetResources) == 0 {
+		return true
+	}
+
+	if testCaseResult.TargetResources == nil || len(testCaseResult.TargetResources.NonCompliant) == 0 {
+		return false
+	}
+
+	for _, nonCompliantResource := range testCaseResult.TargetResources.NonCompliant {
+		matched := false
+		for _, matcher := range waiver.Spec.TargetResources {
+			if matchesTargetResource(matcher, nonCompliantResource) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Looks for a waiver covering the failed test case. Non-expired waivers take precedence over
+// expired ones. Returns true if the test case was waived, that is, a non-expired waiver was found.
+// If only expired waivers were found, the test case keeps its failed state but the waiver is
+// recorded as expired so it can be renewed.
+func applyWaivers(testCaseResult *cnfcertificationsv1alpha1.TestCaseResult, waivers []cnfcertificationsv1alpha1.CnfCertificationWaiver, now metav1.Time) bool {
+	var expiredWaiver *cnfcertificationsv1alpha1.CnfCertificationWaiver
+	for i := range waivers {
+		waiver := &waivers[i]
+		if !waiverCoversTestCase(waiver, testCaseResult) {
+			continue
+		}
+
+		if waiver.IsExpired(now) {
+			expiredWaiver = waiver
+			continue
+		}
+
+		logrus.Infof("Failed tc %s has been waived by %s (approver: %s)", testCaseResult.TestCaseName, waiver.Name, waiver.Spec.Approver)
+		testCaseResult.Result = cnfcertificationsv1alpha1.StatusStateWaived
+		testCaseResult.Waiver = newTestCaseWaiver(waiver, false)
+		return true
+	}
+
+	if expiredWaiver != nil {
+		logrus.Warnf("Waiver %s for failed tc %s expired on %s", expiredWaiver.Name, testCaseResult.TestCaseName, expiredWaiver.Spec.ExpirationDate)
+		testCaseResult.Waiver = newTestCaseWaiver(expiredWaiver, true)
+	}
+
+	return false
+}
+
+func newTestCaseWaiver(waiver *cnfcertificationsv1alpha1.CnfCertificationWaiver, expired bool) *cnfcertificationsv1alpha1.TestCaseWaiver {
+	return &cnfcertificationsv1alpha1.TestCaseWaiver{
+		Name:           waiver.Name,
+		Justification:  waiver.Spec.Justification,
+		Approver:       waiver.Spec.Approver,
+		ExpirationDate: waiver.Spec.ExpirationDate,
+		Expired:        expired,
+	}
+}
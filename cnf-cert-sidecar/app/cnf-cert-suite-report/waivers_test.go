@@ -0,0 +1,176 @@
+package cnfcertsuitereport
+
+import (
+	"testing"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newWaiver(name, tcName string, expiresInHours int, matchers ...cnfcertificationsv1alpha1.TargetResource) cnfcertificationsv1alpha1.CnfCertificationWaiver {
+	waiver := cnfcertificationsv1alpha1.CnfCertificationWaiver{
+		Spec: cnfcertificationsv1alpha1.CnfCertificationWaiverSpec{
+			TestCaseName:    tcName,
+			TargetResources: matchers,
+			Justification:   "accepted",
+			Approver:        "team",
+			ExpirationDate:  metav1.NewTime(time.Now().Add(time.Duration(expiresInHours) * time.Hour)),
+		},
+	}
+	waiver.Name = name
+	return waiver
+}
+
+func newFailedTestCase(tcName string, nonCompliant ...cnfcertificationsv1alpha1.TargetResource) *cnfcertificationsv1alpha1.TestCaseResult {
+	testCaseResult := &cnfcertificationsv1alpha1.TestCaseResult{
+		TestCaseName: tcName,
+		Result:       cnfcertificationsv1alpha1.StatusStateFailed,
+	}
+	if len(nonCompliant) > 0 {
+		testCaseResult.TargetResources = &cnfcertificationsv1alpha1.TargetResources{NonCompliant: nonCompliant}
+	}
+	return testCaseResult
+}
+
+func Test_waiverCoversTestCase(t *testing.T) {
+	pod1 := cnfcertificationsv1alpha1.TargetResource{"Namespace": "cnf", "PodName": "pod1", "ContainerName": "c1"}
+	pod2 := cnfcertificationsv1alpha1.TargetResource{"Namespace": "cnf", "PodName": "pod2", "ContainerName": "c1"}
+
+	tests := []struct {
+		name     string
+		waiver   cnfcertificationsv1alpha1.CnfCertificationWaiver
+		testCase *cnfcertificationsv1alpha1.TestCaseResult
+		want     bool
+	}{
+		{ // Test case #1 - Different test case
+			name:     "Waiver for another test case doesn't apply",
+			waiver:   newWaiver("w1", "tc2", 1),
+			testCase: newFailedTestCase("tc1", pod1),
+			want:     false,
+		},
+		{ // Test case #2 - No matchers
+			name:     "Waiver without matchers applies to any non-compliant resource",
+			waiver:   newWaiver("w1", "tc1", 1),
+			testCase: newFailedTestCase("tc1", pod1, pod2),
+			want:     true,
+		},
+		{ // Test case #3 - Matcher subset
+			name:     "Matcher with a subset of the resource's keys matches it",
+			waiver:   newWaiver("w1", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"Namespace": "cnf"}),
+			testCase: newFailedTestCase("tc1", pod1, pod2),
+			want:     true,
+		},
+		{ // Test case #4 - Matcher value mismatch
+			name:     "Matcher with a different value doesn't match",
+			waiver:   newWaiver("w1", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"Namespace": "other"}),
+			testCase: newFailedTestCase("tc1", pod1),
+			want:     false,
+		},
+		{ // Test case #5 - Matcher key not in resource
+			name:     "Matcher with a key not found in the resource doesn't match",
+			waiver:   newWaiver("w1", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"Namespace": "cnf", "NodeName": "n1"}),
+			testCase: newFailedTestCase("tc1", pod1),
+			want:     false,
+		},
+		{ // Test case #6 - Not all resources covered
+			name:     "Waiver doesn't apply if a non-compliant resource isn't matched",
+			waiver:   newWaiver("w1", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"PodName": "pod1"}),
+			testCase: newFailedTestCase("tc1", pod1, pod2),
+			want:     false,
+		},
+		{ // Test case #7 - All resources covered by several matchers
+			name: "Waiver applies if every non-compliant resource is matched by some matcher",
+			waiver: newWaiver("w1", "tc1", 1,
+				cnfcertificationsv1alpha1.TargetResource{"PodName": "pod1"},
+				cnfcertificationsv1alpha1.TargetResource{"PodName": "pod2"}),
+			testCase: newFailedTestCase("tc1", pod1, pod2),
+			want:     true,
+		},
+		{ // Test case #8 - Matchers without non-compliant resources
+			name:     "Waiver with matchers doesn't apply to a test case without non-compliant resources",
+			waiver:   newWaiver("w1", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"PodName": "pod1"}),
+			testCase: newFailedTestCase("tc1"),
+			want:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, waiverCoversTestCase(&tc.waiver, tc.testCase))
+		})
+	}
+}
+
+func Test_applyWaivers(t *testing.T) {
+	pod1 := cnfcertificationsv1alpha1.TargetResource{"Namespace": "cnf", "PodName": "pod1"}
+
+	tests := []struct {
+		name              string
+		waivers           []cnfcertificationsv1alpha1.CnfCertificationWaiver
+		wantWaived        bool
+		wantWaiverName    string
+		wantWaiverExpired bool
+	}{
+		{ // Test case #1 - No waivers
+			name:       "Test case without waivers isn't waived",
+			waivers:    nil,
+			wantWaived: false,
+		},
+		{ // Test case #2 - Active waiver
+			name:           "Test case covered by an active waiver is waived",
+			waivers:        []cnfcertificationsv1alpha1.CnfCertificationWaiver{newWaiver("active", "tc1", 1)},
+			wantWaived:     true,
+			wantWaiverName: "active",
+		},
+		{ // Test case #3 - Expired waiver
+			name:              "Test case covered only by an expired waiver keeps failing",
+			waivers:           []cnfcertificationsv1alpha1.CnfCertificationWaiver{newWaiver("expired", "tc1", -1)},
+			wantWaived:        false,
+			wantWaiverName:    "expired",
+			wantWaiverExpired: true,
+		},
+		{ // Test case #4 - Active waiver after an expired one
+			name: "Active waiver takes precedence over an expired one listed before it",
+			waivers: []cnfcertificationsv1alpha1.CnfCertificationWaiver{
+				newWaiver("expired", "tc1", -1),
+				newWaiver("active", "tc1", 1),
+			},
+			wantWaived:     true,
+			wantWaiverName: "active",
+		},
+		{ // Test case #5 - Active waiver not covering the resources
+			name: "Expired waiver is recorded if the active one doesn't cover the test case",
+			waivers: []cnfcertificationsv1alpha1.CnfCertificationWaiver{
+				newWaiver("active", "tc1", 1, cnfcertificationsv1alpha1.TargetResource{"PodName": "pod2"}),
+				newWaiver("expired", "tc1", -1),
+			},
+			wantWaived:        false,
+			wantWaiverName:    "expired",
+			wantWaiverExpired: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testCase := newFailedTestCase("tc1", pod1)
+			waived := applyWaivers(testCase, tc.waivers, metav1.Now())
+			assert.Equal(t, tc.wantWaived, waived)
+
+			if tc.wantWaived {
+				assert.Equal(t, cnfcertificationsv1alpha1.StatusStateWaived, testCase.Result)
+			} else {
+				assert.Equal(t, cnfcertificationsv1alpha1.StatusStateFailed, testCase.Result)
+			}
+
+			if tc.wantWaiverName == "" {
+				assert.Nil(t, testCase.Waiver)
+				return
+			}
+			assert.NotNil(t, testCase.Waiver)
+			assert.Equal(t, tc.wantWaiverName, testCase.Waiver.Name)
+			assert.Equal(t, tc.wantWaiverExpired, testCase.Waiver.Expired)
+		})
+	}
+}
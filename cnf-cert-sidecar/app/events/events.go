@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const component = "cnf-certsuite-sidecar"
+
+// Reasons of the events recorded in the CnfCertificationSuiteRun by the sidecar.
+const (
+	ReasonResultsPublished     = "ResultsPublished"
+	ReasonVerdict              = "Verdict"
+	ReasonSidecarPublishFailed = "SidecarPublishFailed"
+	ReasonResultsExportFailed  = "ResultsExportFailed"
+)
+
+// Record creates an event in the CnfCertificationSuiteRun CR. Failures are only logged, as events
+// are informative.
+func Record(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, eventType, reason, messageFmt string, args ...interface{}) {
+	now := metav1.Now()
+	event := corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v.%x", runCR.Name, now.UnixNano()),
+			Namespace: runCR.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      cnfcertificationsv1alpha1.GroupVersion.String(),
+			Kind:            "CnfCertificationSuiteRun",
+			Name:            runCR.Name,
+			Namespace:       runCR.Namespace,
+			UID:             runCR.UID,
+			ResourceVersion: runCR.ResourceVersion,
+		},
+		Reason:              reason,
+		Message:             fmt.Sprintf(messageFmt, args...),
+		Type:                eventType,
+		Source:              corev1.EventSource{Component: component},
+		ReportingController: component,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+	}
+
+	err := k8sClient.Create(context.TODO(), &event)
+	if err != nil {
+		logrus.Errorf("Failed to record event %s in CnfCertificationSuiteRun %s (ns %s): %v", reason, runCR.Name, runCR.Namespace, err)
+	}
+}
+
+// RecordVerdict records the verdict of the run, as a warning if the certification didn't pass.
+func RecordVerdict(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) {
+	report := runCR.Status.Report
+	summary := report.Summary
+	Record(k8sClient, runCR, corev1.EventTypeNormal, ReasonResultsPublished,
+		"Results published: %d test cases, %d passed, %d skipped, %d failed, %d errored, %d waived",
+		summary.Total, summary.Passed, summary.Skipped, summary.Failed, summary.Errored, summary.Waived)
+
+	eventType := corev1.EventTypeNormal
+	if report.Verdict == cnfcertificationsv1alpha1.StatusVerdictFail || report.Verdict == cnfcertificationsv1alpha1.StatusVerdictError {
+		eventType = corev1.EventTypeWarning
+	}
+	Record(k8sClient, runCR, eventType, ReasonVerdict, "CNF Certification verdict: %s", report.Verdict)
+}
@@ -9,7 +9,17 @@ import (
 	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/claim"
 	cnfcertsuitereport "github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/cnf-cert-suite-report"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/events"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/progress"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/resultsexport"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/artifacts"
+	certsuiteprogress "github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/progress"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/junit"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/render"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/report/sarif"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -18,19 +28,26 @@ import (
 )
 
 const (
-	podNamespaceEnvVar = "MY_POD_NAMESPACE"
-	runCrNameEnvVar    = "RUN_CR_NAME"
+	podNameEnvVar        = "MY_POD_NAME"
+	podNamespaceEnvVar   = "MY_POD_NAMESPACE"
+	runCrNameEnvVar      = "RUN_CR_NAME"
+	runCrNamespaceEnvVar = "RUN_CR_NAMESPACE"
 )
 
 const (
 	sideCarResultsFolderEnvVar = "TNF_RESULTS_FOLDER"
 	claimFileName              = "claim.json"
 	multiplier                 = 5
+	progressUpdateInterval     = 10 * time.Second
 )
 
 func handleClaimFile(k8sClient client.Client) {
-	namespace := os.Getenv(podNamespaceEnvVar)
 	runCRname := os.Getenv(runCrNameEnvVar)
+	// The pod may run in a different namespace than the CR's one.
+	namespace, found := os.LookupEnv(runCrNamespaceEnvVar)
+	if !found {
+		namespace = os.Getenv(podNamespaceEnvVar)
+	}
 
 	claimFolder := os.Getenv(sideCarResultsFolderEnvVar)
 	claimFilePath := claimFolder + "/" + claimFileName
@@ -38,9 +55,28 @@ func handleClaimFile(k8sClient client.Client) {
 	logrus.Infof("Claim file: %v", claimFilePath)
 	logrus.Infof("CnfCertificationSuiteRun CR: %s/%s", namespace, runCRname)
 
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: runCRname, Namespace: namespace}, &runCR)
+	if err != nil {
+		logrus.Fatalf("Failed to get CnfCertificationSuiteRun CR %s (ns %s)", runCRname, namespace)
+	}
+
+	// Set the run's progress from the certsuite's log while waiting for the claim file.
+	progressFollower := progress.Follow(k8sClient, &runCR, claimFolder+"/"+certsuiteprogress.LogFileName, progressUpdateInterval)
+
 	for {
 		_, err := os.Stat(claimFilePath)
 		if os.IsNotExist(err) {
+			if exitCode, terminated := getCertSuiteContainerExitCode(k8sClient); terminated {
+				// The claim file may have been written right before the container exited.
+				if _, err := os.Stat(claimFilePath); os.IsNotExist(err) {
+					progressFollower.Stop()
+					storeCertSuiteLog(k8sClient, &runCR, claimFolder)
+					failPublish(k8sClient, &runCR, "CNF Cert Suite container exited with code %d without producing a claim file", exitCode)
+				}
+				continue
+			}
+
 			logrus.Warnf("Claim file not found yet. Waiting 5 secs...")
 			time.Sleep(multiplier * time.Second)
 			continue
@@ -51,19 +87,10 @@ func handleClaimFile(k8sClient client.Client) {
 		time.Sleep(multiplier * time.Second)
 
 		logrus.Infof("Claim file found at %v", claimFilePath)
-		claimBytes, err := os.ReadFile(claimFilePath)
-		if err != nil {
-			logrus.Fatalf("Failed to read claim file %s: %v", claimFilePath, err)
-		}
 
-		claimContent := claim.Schema{}
-		err = json.Unmarshal(claimBytes, &claimContent)
-		if err != nil {
-			logrus.Fatalf("Failed to unmarshal claim json: %v", err)
-		}
+		runProgress := progressFollower.Stop()
 
-		// Get the CnfCertificationSuiteRun CR
-		runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+		// Get the CnfCertificationSuiteRun CR again, as its status may have changed.
 		err = k8sClient.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      runCRname,
@@ -74,18 +101,162 @@ func handleClaimFile(k8sClient client.Client) {
 			logrus.Fatalf("Failed to get CnfCertificationSuiteRun CR %s (ns %s)", runCRname, namespace)
 		}
 
-		cnfcertsuitereport.SetRunCRStatus(&runCR, &claimContent)
+		claimBytes, err := os.ReadFile(claimFilePath)
+		if err != nil {
+			failPublish(k8sClient, &runCR, "Failed to read claim file %s: %v", claimFilePath, err)
+		}
+
+		claimContent := claim.Schema{}
+		err = json.Unmarshal(claimBytes, &claimContent)
+		if err != nil {
+			failPublish(k8sClient, &runCR, "Failed to unmarshal claim json: %v", err)
+		}
+
+		// Get the waivers that may apply to the failed test cases.
+		waivers := cnfcertificationsv1alpha1.CnfCertificationWaiverList{}
+		err = k8sClient.List(context.TODO(), &waivers, client.InNamespace(namespace))
+		if err != nil {
+			logrus.Errorf("Failed to list CnfCertificationWaivers (ns %s), no waivers will be applied: %v", namespace, err)
+		}
+
+		cnfcertsuitereport.SetRunCRStatus(&runCR, &claimContent, waivers.Items)
+		runCR.Status.Progress = runProgress
 
 		err = k8sClient.Status().Update(context.TODO(), &runCR)
 		if err != nil {
-			logrus.Fatalf("Failed to update CnfCertificationSuiteRun.Status object object: %v", err)
+			failPublish(k8sClient, &runCR, "Failed to update CnfCertificationSuiteRun.Status object object: %v", err)
 		}
 
 		logrus.Infof("CnfCertificationSuiteRun CR's status updated successfully with results:\n%v", runCR.Status.Report.Results)
+		storeArtifacts(k8sClient, &runCR, claimFolder)
+		events.RecordVerdict(k8sClient, &runCR)
+		resultsexport.Export(k8sClient, &runCR, claimBytes)
 		break
 	}
 }
 
+// Exports the run CR's report as JUnit XML, its non-compliant resources as SARIF, and renders it as
+// HTML and Markdown, and stores them in the run's artifacts config map, along with the certsuite's
+// log file. It's done once the report is published in the run CR, so failing to do it doesn't
+// prevent nor delay it.
+func storeArtifacts(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, resultsFolder string) {
+	report := runCR.Status.Report
+	exporters := map[string]func() ([]byte, error){
+		artifacts.JUnitKey:    func() ([]byte, error) { return junit.Export(runCR.Name, report) },
+		artifacts.SARIFKey:    func() ([]byte, error) { return sarif.Export(runCR.Namespace, runCR.Name, report) },
+		artifacts.HTMLKey:     func() ([]byte, error) { return render.HTML(runCR.Namespace, runCR.Name, report) },
+		artifacts.MarkdownKey: func() ([]byte, error) { return render.Markdown(runCR.Namespace, runCR.Name, report) },
+	}
+
+	runArtifacts := map[string][]byte{}
+	for key, export := range exporters {
+		data, err := export()
+		if err != nil {
+			logrus.Errorf("Failed to export artifact %s: %v", key, err)
+			continue
+		}
+		runArtifacts[key] = data
+	}
+
+	if certSuiteLog, err := readCertSuiteLog(resultsFolder); err != nil {
+		logrus.Errorf("Failed to read the certsuite's log file: %v", err)
+	} else {
+		runArtifacts[artifacts.CertSuiteLogKey] = certSuiteLog
+	}
+
+	if len(runArtifacts) == 0 {
+		return
+	}
+
+	configMapName, dropped, err := artifacts.Store(context.TODO(), k8sClient, runCR, runArtifacts)
+	if err != nil {
+		logrus.Errorf("Failed to store the run's artifacts: %v", err)
+		return
+	}
+
+	logrus.Infof("Run's artifacts stored in config map %s", configMapName)
+	setArtifactsStatus(k8sClient, runCR, configMapName, dropped)
+}
+
+// Sets the artifacts config map in the run CR's status, along with the artifacts that were too big
+// to be stored in it.
+func setArtifactsStatus(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, configMapName string, dropped []string) {
+	if len(dropped) > 0 {
+		logrus.Errorf("Artifacts %v are too big to be stored in config map %s", dropped, configMapName)
+	}
+
+	runCR.Status.ArtifactsConfigMap = configMapName
+	runCR.Status.DroppedArtifacts = dropped
+
+	// Merge patch, so it doesn't conflict with the controller's status updates.
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"artifactsConfigMap": configMapName,
+			"droppedArtifacts":   dropped,
+		},
+	})
+	if err != nil {
+		logrus.Errorf("Failed to marshal the artifacts status patch: %v", err)
+		return
+	}
+
+	err = k8sClient.Status().Patch(context.TODO(), runCR.DeepCopy(), client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		logrus.Errorf("Failed to set the artifacts config map in the CnfCertificationSuiteRun's status: %v", err)
+	}
+}
+
+// Returns the certsuite's log file from the results folder, truncated to be stored as an artifact.
+func readCertSuiteLog(resultsFolder string) ([]byte, error) {
+	certSuiteLog, err := os.ReadFile(resultsFolder + "/" + certsuiteprogress.LogFileName)
+	if err != nil {
+		return nil, err
+	}
+	return artifacts.TruncateLog(certSuiteLog), nil
+}
+
+// Stores the certsuite's log file in the run's artifacts config map, when there are no results.
+func storeCertSuiteLog(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, resultsFolder string) {
+	certSuiteLog, err := readCertSuiteLog(resultsFolder)
+	if err != nil {
+		logrus.Errorf("Failed to read the certsuite's log file: %v", err)
+		return
+	}
+
+	configMapName, dropped, err := artifacts.Store(context.TODO(), k8sClient, runCR, map[string][]byte{artifacts.CertSuiteLogKey: certSuiteLog})
+	if err != nil {
+		logrus.Errorf("Failed to store the certsuite's log file: %v", err)
+		return
+	}
+	logrus.Infof("Certsuite's log file stored in config map %s", configMapName)
+	setArtifactsStatus(k8sClient, runCR, configMapName, dropped)
+}
+
+// Returns the exit code of the CNF Cert Suite container running in this pod, and whether it has
+// terminated.
+func getCertSuiteContainerExitCode(k8sClient client.Client) (exitCode int32, terminated bool) {
+	pod := corev1.Pod{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: os.Getenv(podNameEnvVar), Namespace: os.Getenv(podNamespaceEnvVar)}, &pod)
+	if err != nil {
+		logrus.Warnf("Failed to get the sidecar's pod to check the CNF Cert Suite container's state: %v", err)
+		return 0, false
+	}
+
+	for i := range pod.Status.ContainerStatuses {
+		containerStatus := &pod.Status.ContainerStatuses[i]
+		if containerStatus.Name == definitions.CnfCertSuiteContainerName && containerStatus.State.Terminated != nil {
+			return containerStatus.State.Terminated.ExitCode, true
+		}
+	}
+	return 0, false
+}
+
+// Records the failure to publish the results in the run CR before exiting.
+func failPublish(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, format string, args ...interface{}) {
+	events.Record(k8sClient, runCR, corev1.EventTypeWarning, events.ReasonSidecarPublishFailed, format, args...)
+	logrus.Fatalf(format, args...)
+}
+
 // This CNF Certification sidecar container expects to be running in the same
 // pod as the CNF Cert Suite container.
 //
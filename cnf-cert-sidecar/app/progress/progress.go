@@ -0,0 +1,140 @@
+package progress
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	certsuiteprogress "github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/progress"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Follower tails the certsuite's log file and periodically sets the run's progress in the
+// CnfCertificationSuiteRun CR's status.
+type Follower struct {
+	k8sClient   client.Client
+	runCR       *cnfcertificationsv1alpha1.CnfCertificationSuiteRun
+	logFilePath string
+	tracker     *certsuiteprogress.Tracker
+
+	logFile *os.File
+	reader  *bufio.Reader
+	// Last line read without its end of line, as the certsuite may still be writing it.
+	partialLine string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Follow starts following the certsuite's log file, that may not exist yet, updating the run's
+// progress every interval until Stop is called.
+func Follow(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, logFilePath string, interval time.Duration) *Follower {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := Follower{
+		k8sClient:   k8sClient,
+		runCR:       runCR.DeepCopy(),
+		logFilePath: logFilePath,
+		tracker:     certsuiteprogress.NewTracker(runCR.Spec.LabelsFilter),
+		cancel:      cancel,
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.readLines()
+				if f.tracker.Changed() {
+					f.patchProgress(ctx)
+				}
+			}
+		}
+	}()
+
+	return &f
+}
+
+// Feeds the tracker with the lines written in the log file since the last read.
+func (f *Follower) readLines() {
+	if f.logFile == nil {
+		logFile, err := os.Open(f.logFilePath)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logrus.Warnf("Failed to open certsuite log file %s: %v", f.logFilePath, err)
+			}
+			return
+		}
+		f.logFile = logFile
+		f.reader = bufio.NewReader(logFile)
+	}
+
+	for {
+		line, err := f.reader.ReadString('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				logrus.Warnf("Failed to read certsuite log file %s: %v", f.logFilePath, err)
+			}
+			f.partialLine += line
+			return
+		}
+		f.tracker.ParseLine(f.partialLine + line)
+		f.partialLine = ""
+	}
+}
+
+// Returns the current progress, with its update time.
+func (f *Follower) getProgress() *cnfcertificationsv1alpha1.RunProgress {
+	progress := f.tracker.Progress()
+	progress.LastUpdateTime = metav1.Now()
+	return &progress
+}
+
+// Sets the progress in the run CR's status with a merge patch, so it doesn't conflict with the
+// controller's status updates.
+func (f *Follower) patchProgress(ctx context.Context) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"progress": f.getProgress()},
+	})
+	if err != nil {
+		logrus.Errorf("Failed to marshal the run's progress: %v", err)
+		return
+	}
+
+	err = f.k8sClient.Status().Patch(ctx, f.runCR.DeepCopy(), client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		logrus.Warnf("Failed to set the progress of CnfCertificationSuiteRun %s (ns %s): %v", f.runCR.Name, f.runCR.Namespace, err)
+	}
+}
+
+// Stop stops following the log file, and returns the final progress after reading its last lines.
+func (f *Follower) Stop() *cnfcertificationsv1alpha1.RunProgress {
+	f.cancel()
+	f.wg.Wait()
+
+	f.readLines()
+	if f.partialLine != "" {
+		f.tracker.ParseLine(f.partialLine)
+		f.partialLine = ""
+	}
+	if f.logFile != nil {
+		f.logFile.Close()
+	}
+
+	progress := f.getProgress()
+	progress.CurrentTest = ""
+	return progress
+}
@@ -0,0 +1,105 @@
+package resultsexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/cnf-cert-sidecar/app/events"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/collector"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultMaxAttempts = 3
+	// Backoff before retrying a failed push, doubled on every attempt.
+	retryBackoff = 5 * time.Second
+)
+
+// Returns the collector's credentials from the secret in the run CR's namespace, if set.
+func getCredentials(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) (*collector.Credentials, error) {
+	secretName := runCR.Spec.ResultsExport.CredentialsSecretName
+	if secretName == "" {
+		return &collector.Credentials{}, nil
+	}
+
+	secret := corev1.Secret{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: runCR.Namespace}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collector's credentials secret %s: %w", secretName, err)
+	}
+
+	return &collector.Credentials{
+		ExecutedBy:  string(secret.Data[collector.ExecutedBySecretKey]),
+		PartnerName: string(secret.Data[collector.PartnerNameSecretKey]),
+		Password:    string(secret.Data[collector.PasswordSecretKey]),
+	}, nil
+}
+
+func push(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, claim []byte) (attempts int, err error) {
+	credentials, err := getCredentials(k8sClient, runCR)
+	if err != nil {
+		return 0, err
+	}
+
+	report, err := json.Marshal(runCR.Status.Report)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal the run's report: %w", err)
+	}
+
+	maxAttempts := runCR.Spec.ResultsExport.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	results := collector.Results{Claim: claim, Report: report}
+	return collector.Deliver(context.TODO(), runCR.Spec.ResultsExport.Endpoint, &results, credentials, maxAttempts, retryBackoff)
+}
+
+// Export pushes the run's report and claim file to the collector set in the run CR, if any, and
+// sets its delivery status in the run CR's status. Failing to do it doesn't fail the run, as its
+// results are already published in the run CR.
+func Export(k8sClient client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, claim []byte) {
+	if runCR.Spec.ResultsExport == nil {
+		return
+	}
+
+	endpoint := runCR.Spec.ResultsExport.Endpoint
+	logrus.Infof("Pushing the run's results to collector %s", endpoint)
+
+	status := cnfcertificationsv1alpha1.ResultsExportStatus{Endpoint: endpoint}
+	attempts, err := push(k8sClient, runCR, claim)
+	status.Attempts = attempts
+	status.Time = metav1.Now()
+	if err != nil {
+		logrus.Errorf("Failed to push the run's results to collector %s: %v", endpoint, err)
+		status.Error = err.Error()
+		events.Record(k8sClient, runCR, corev1.EventTypeWarning, events.ReasonResultsExportFailed,
+			"Failed to push the results to collector %s after %d attempts: %v", endpoint, attempts, err)
+	} else {
+		logrus.Infof("Run's results pushed to collector %s", endpoint)
+		status.Delivered = true
+	}
+
+	// Merge patch, so it doesn't conflict with the controller's status updates.
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"resultsExport": status},
+	})
+	if err != nil {
+		logrus.Errorf("Failed to marshal the results export status: %v", err)
+		return
+	}
+
+	err = k8sClient.Status().Patch(context.TODO(), runCR.DeepCopy(), client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		logrus.Errorf("Failed to set the results export status of CnfCertificationSuiteRun %s (ns %s): %v", runCR.Name, runCR.Namespace, err)
+		return
+	}
+	runCR.Status.ResultsExport = &status
+}
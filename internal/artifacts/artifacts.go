@@ -0,0 +1,231 @@
+// Package artifacts stores the files generated for a CNF Certification Suite run, e.g. its JUnit
+// XML report, in a config map in the run CR's namespace, so they can be retrieved from the cluster.
+package artifacts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// Keys of the artifacts.
+const (
+	JUnitKey    = "junit.xml"
+	SARIFKey    = "results.sarif"
+	HTMLKey     = "report.html"
+	MarkdownKey = "report.md"
+	// Log file written by the certsuite in its results folder.
+	CertSuiteLogKey = "certsuite.log"
+	// Output of the certsuite container.
+	CertSuiteContainerLogKey = "certsuite-container.log"
+)
+
+const (
+	// Artifacts bigger than this are stored gzipped, in the binary data, with the gzipSuffix.
+	compressThreshold = 256 * 1024
+	gzipSuffix        = ".gz"
+	// Max size of a config map's data, with a margin for its metadata.
+	maxConfigMapSize = 1000 * 1024
+	// Max size of a stored log. Logs compress well, so their compressed size fits in the config map.
+	maxLogSize = 4 * 1024 * 1024
+)
+
+// ConfigMapName returns the name of the artifacts config map of a run CR.
+func ConfigMapName(runName string) string {
+	return runName + "-artifacts"
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := bytes.Buffer{}
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func removeArtifact(configMap *corev1.ConfigMap, key string) {
+	delete(configMap.Data, key)
+	delete(configMap.BinaryData, key+gzipSuffix)
+}
+
+// Sets the artifact in the config map, compressed if it's too big.
+func setArtifact(configMap *corev1.ConfigMap, key string, data []byte) error {
+	removeArtifact(configMap, key)
+
+	if len(data) <= compressThreshold {
+		configMap.Data[key] = string(data)
+		return nil
+	}
+
+	compressed, err := compress(data)
+	if err != nil {
+		return fmt.Errorf("failed to compress artifact %s: %w", key, err)
+	}
+	configMap.BinaryData[key+gzipSuffix] = compressed
+	return nil
+}
+
+func getConfigMapSize(configMap *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range configMap.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range configMap.BinaryData {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// TruncateLog returns the last part of the log that can be stored as an artifact.
+func TruncateLog(log []byte) []byte {
+	if len(log) <= maxLogSize {
+		return log
+	}
+
+	header := fmt.Sprintf("[... %d bytes truncated ...]\n", len(log)-maxLogSize)
+	return append([]byte(header), log[len(log)-maxLogSize:]...)
+}
+
+// Store sets the artifacts in the run CR's artifacts config map, creating it if needed, and
+// returns its name along with the keys of the artifacts that were dropped, as they didn't fit in
+// the config map. Their previous version, if any, is removed. The config map has the run CR's
+// labels, so it's removed along with the run CR, and the run CR as owner.
+func Store(ctx context.Context, cl client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, artifacts map[string][]byte) (name string, dropped []string, err error) {
+	name = ConfigMapName(runCR.Name)
+	// The config map may be set by both the sidecar and the controller, whose cache may not have
+	// the latest version yet, so conflicts are retried.
+	err = retry.OnError(retry.DefaultBackoff, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		dropped, err = store(ctx, cl, runCR, name, artifacts)
+		return err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, dropped, nil
+}
+
+func store(ctx context.Context, cl client.Client, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, name string, artifacts map[string][]byte) ([]string, error) {
+	configMap := corev1.ConfigMap{}
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: runCR.Namespace}, &configMap)
+	found := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get artifacts config map %s: %w", name, err)
+	}
+
+	if !found {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: runCR.Namespace,
+				Labels: map[string]string{
+					definitions.RunCrNameLabel:      runCR.Name,
+					definitions.RunCrNamespaceLabel: runCR.Namespace,
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: cnfcertificationsv1alpha1.GroupVersion.String(),
+					Kind:       "CnfCertificationSuiteRun",
+					Name:       runCR.Name,
+					UID:        runCR.UID,
+				}},
+			},
+		}
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	if configMap.BinaryData == nil {
+		configMap.BinaryData = map[string][]byte{}
+	}
+
+	// The artifacts are set from the smallest one, so an artifact too big to fit in the config map
+	// only prevents itself from being stored.
+	keys := make([]string, 0, len(artifacts))
+	for key := range artifacts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(artifacts[keys[i]]) != len(artifacts[keys[j]]) {
+			return len(artifacts[keys[i]]) < len(artifacts[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+
+	var dropped []string
+	for _, key := range keys {
+		err = setArtifact(&configMap, key, artifacts[key])
+		if err != nil {
+			return nil, err
+		}
+
+		if getConfigMapSize(&configMap) > maxConfigMapSize {
+			removeArtifact(&configMap, key)
+			dropped = append(dropped, key)
+		}
+	}
+
+	if found {
+		err = cl.Update(ctx, &configMap)
+	} else {
+		err = cl.Create(ctx, &configMap)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store artifacts in config map %s: %w", name, err)
+	}
+
+	return dropped, nil
+}
+
+// Load returns the artifact with the given key from the artifacts config map, uncompressed.
+func Load(configMap *corev1.ConfigMap, key string) ([]byte, error) {
+	if data, found := configMap.Data[key]; found {
+		return []byte(data), nil
+	}
+
+	compressed, found := configMap.BinaryData[key+gzipSuffix]
+	if !found {
+		return nil, fmt.Errorf("artifact %s not found in config map %s", key, configMap.Name)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to uncompress artifact %s: %w", key, err)
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// Keys returns the keys of the artifacts stored in the config map.
+func Keys(configMap *corev1.ConfigMap) []string {
+	keys := []string{}
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	for key := range configMap.BinaryData {
+		keys = append(keys, strings.TrimSuffix(key, gzipSuffix))
+	}
+	return keys
+}
@@ -0,0 +1,96 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+func TestStoreAndLoad(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{ObjectMeta: v1.ObjectMeta{Name: "cnf-run", Namespace: "cnf-ns", UID: "1234"}}
+
+	name, dropped, err := Store(context.TODO(), cl, runCR, map[string][]byte{JUnitKey: []byte("<testsuites/>")})
+	assert.Nil(t, err)
+	assert.Equal(t, "cnf-run-artifacts", name)
+	assert.Empty(t, dropped)
+
+	configMap := corev1.ConfigMap{}
+	assert.Nil(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "cnf-ns"}, &configMap))
+	assert.Equal(t, "cnf-run", configMap.Labels[definitions.RunCrNameLabel])
+	assert.Equal(t, "cnf-ns", configMap.Labels[definitions.RunCrNamespaceLabel])
+	assert.Equal(t, types.UID("1234"), configMap.OwnerReferences[0].UID)
+	assert.Equal(t, "<testsuites/>", configMap.Data[JUnitKey])
+
+	// Big artifacts are compressed, and updating an artifact replaces it.
+	big := []byte(strings.Repeat("<testcase/>", compressThreshold))
+	_, dropped, err = Store(context.TODO(), cl, runCR, map[string][]byte{JUnitKey: big, "other.txt": []byte("other")})
+	assert.Nil(t, err)
+	assert.Empty(t, dropped)
+
+	assert.Nil(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "cnf-ns"}, &configMap))
+	assert.NotContains(t, configMap.Data, JUnitKey)
+	assert.Contains(t, configMap.BinaryData, JUnitKey+gzipSuffix)
+	assert.ElementsMatch(t, []string{JUnitKey, "other.txt"}, Keys(&configMap))
+
+	data, err := Load(&configMap, JUnitKey)
+	assert.Nil(t, err)
+	assert.Equal(t, big, data)
+	data, err = Load(&configMap, "other.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, "other", string(data))
+
+	_, err = Load(&configMap, "not-found")
+	assert.NotNil(t, err)
+}
+
+func TestStoreTooBig(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{ObjectMeta: v1.ObjectMeta{Name: "cnf-run", Namespace: "cnf-ns"}}
+
+	// Random data doesn't compress, so it doesn't fit in the config map.
+	tooBig := make([]byte, maxConfigMapSize)
+	_, err := rand.Read(tooBig)
+	assert.Nil(t, err)
+
+	name, dropped, err := Store(context.TODO(), cl, runCR, map[string][]byte{JUnitKey: []byte("<testsuites/>"), HTMLKey: []byte("<html/>")})
+	assert.Nil(t, err)
+	assert.Empty(t, dropped)
+
+	// Only the artifacts that don't fit are dropped, along with their previous version.
+	_, dropped, err = Store(context.TODO(), cl, runCR, map[string][]byte{
+		HTMLKey:         tooBig,
+		CertSuiteLogKey: []byte("log"),
+		MarkdownKey:     tooBig[:maxConfigMapSize/2],
+		SARIFKey:        tooBig[:maxConfigMapSize/2],
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{SARIFKey, HTMLKey}, dropped)
+
+	configMap := corev1.ConfigMap{}
+	assert.Nil(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "cnf-ns"}, &configMap))
+	assert.ElementsMatch(t, []string{JUnitKey, CertSuiteLogKey, MarkdownKey}, Keys(&configMap))
+	assert.LessOrEqual(t, getConfigMapSize(&configMap), maxConfigMapSize)
+}
+
+func TestTruncateLog(t *testing.T) {
+	log := []byte("short log")
+	assert.Equal(t, log, TruncateLog(log))
+
+	log = []byte(strings.Repeat("a", maxLogSize) + "end")
+	truncated := TruncateLog(log)
+	assert.True(t, strings.HasPrefix(string(truncated), "[... 3 bytes truncated ...]\n"))
+	assert.True(t, strings.HasSuffix(string(truncated), "end"))
+	assert.Len(t, truncated, maxLogSize+len("[... 3 bytes truncated ...]\n"))
+}
@@ -0,0 +1,63 @@
+// Package catalog holds the list of test cases of the CNF Certification Suite version the operator
+// is released with, so run CRs can be checked without running the certsuite.
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// TestCase holds the identification of a CNF Certification Suite test case.
+type TestCase struct {
+	ID    string   `json:"id"`
+	Suite string   `json:"suite"`
+	Tags  []string `json:"tags"`
+}
+
+// Label matching every test case.
+const AllLabel = "all"
+
+// Labels returns the labels a test case can be selected with: its id, its suite, its tags, and
+// the label matching every test case.
+func (tc *TestCase) Labels() []string {
+	return append([]string{tc.ID, tc.Suite, AllLabel}, tc.Tags...)
+}
+
+var testCases []TestCase
+
+func init() {
+	err := json.Unmarshal(catalogJSON, &testCases)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse embedded test cases catalog: %v", err))
+	}
+}
+
+// TestCases returns every test case of the catalog.
+func TestCases() []TestCase {
+	return testCases
+}
+
+// Get returns the test case with the given id, if found in the catalog.
+func Get(id string) (*TestCase, bool) {
+	for i := range testCases {
+		if testCases[i].ID == id {
+			return &testCases[i], true
+		}
+	}
+	return nil, false
+}
+
+// MatchingTestCases returns the test cases whose labels are matched by the given function.
+func MatchingTestCases(match func(labels []string) bool) []TestCase {
+	matching := []TestCase{}
+	for i := range testCases {
+		if match(testCases[i].Labels()) {
+			matching = append(matching, testCases[i])
+		}
+	}
+	return matching
+}
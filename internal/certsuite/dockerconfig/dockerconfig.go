@@ -0,0 +1,128 @@
+// Package dockerconfig parses the docker config json used by preflight to pull the images and
+// operator bundles under test.
+package dockerconfig
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PreflightSecretKey is the key of the preflight secret's docker config json. Standard
+// kubernetes.io/dockerconfigjson secrets, with the corev1.DockerConfigJsonKey, are accepted too.
+const PreflightSecretKey = "preflight_dockerconfig.json"
+
+const defaultRegistry = "docker.io"
+
+// AuthEntry holds the credentials of a registry.
+type AuthEntry struct {
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Config holds the content of a docker config json.
+type Config struct {
+	Auths map[string]AuthEntry `json:"auths"`
+}
+
+// GetSecretKey returns the key of the secret holding the docker config json, if any.
+func GetSecretKey(secret *corev1.Secret) (string, bool) {
+	for _, key := range []string{PreflightSecretKey, corev1.DockerConfigJsonKey} {
+		if len(secret.Data[key]) > 0 {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// Parse parses the docker config json, checking every registry in its auths has credentials.
+func Parse(data []byte) (*Config, error) {
+	config := Config{}
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker config json: %w", err)
+	}
+
+	// A missing auths field is rejected, but an empty auths map is accepted, e.g. when every image is public.
+	if config.Auths == nil {
+		return nil, fmt.Errorf("docker config json has no auths field")
+	}
+
+	for registry, entry := range config.Auths {
+		if registry == "" {
+			return nil, fmt.Errorf("docker config json has an auths entry with an empty registry")
+		}
+
+		err = entry.validate()
+		if err != nil {
+			return nil, fmt.Errorf("docker config json auths entry for registry %q is invalid: %w", registry, err)
+		}
+	}
+
+	return &config, nil
+}
+
+func (e *AuthEntry) validate() error {
+	if e.Auth == "" {
+		if e.Username == "" || e.Password == "" {
+			return fmt.Errorf("either auth or username and password must be set")
+		}
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+	if err != nil {
+		return fmt.Errorf("auth is not base64 encoded: %w", err)
+	}
+
+	username, _, found := strings.Cut(string(decoded), ":")
+	if !found || username == "" {
+		return fmt.Errorf("auth must be the base64 encoding of \"username:password\"")
+	}
+
+	return nil
+}
+
+// Registries returns the hosts of the registries in the config's auths.
+func (c *Config) Registries() []string {
+	registries := []string{}
+	for registry := range c.Auths {
+		registries = append(registries, normalizeRegistry(registry))
+	}
+	sort.Strings(registries)
+	return registries
+}
+
+// Returns the host of a registry set in an auths entry, which may be a URL, or have a repository
+// path, e.g. "https://index.docker.io/v1/" or "quay.io/my-org".
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(registry, "https://")
+	registry = strings.TrimPrefix(registry, "http://")
+	registry, _, _ = strings.Cut(registry, "/")
+
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return defaultRegistry
+	}
+	return registry
+}
+
+// ImageRegistry returns the registry host of an image reference. Images without a registry
+// are pulled from docker.io.
+func ImageRegistry(image string) string {
+	firstComponent, _, found := strings.Cut(image, "/")
+	if !found {
+		return defaultRegistry
+	}
+
+	if strings.ContainsAny(firstComponent, ".:") || firstComponent == "localhost" {
+		return normalizeRegistry(firstComponent)
+	}
+
+	return defaultRegistry
+}
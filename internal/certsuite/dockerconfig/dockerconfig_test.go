@@ -0,0 +1,59 @@
+package dockerconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantRegistries []string
+		wantError      bool
+	}{
+		{
+			name:           "Auth entries",
+			data:           `{"auths": {"quay.io": {"auth": "dXNlcjpwYXNzd29yZA=="}, "https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNzd29yZA=="}}}`,
+			wantRegistries: []string{"docker.io", "quay.io"},
+		},
+		{
+			name:           "Username and password entry",
+			data:           `{"auths": {"registry.redhat.io/my-org": {"username": "user", "password": "password"}}}`,
+			wantRegistries: []string{"registry.redhat.io"},
+		},
+		{name: "Empty auths", data: `{ "auths": {} }`, wantRegistries: []string{}},
+		{name: "Not a json", data: `auths: {}`, wantError: true},
+		{name: "No auths", data: `{}`, wantError: true},
+		{name: "Empty registry", data: `{"auths": {"": {"auth": "dXNlcjpwYXNzd29yZA=="}}}`, wantError: true},
+		{name: "No credentials", data: `{"auths": {"quay.io": {}}}`, wantError: true},
+		{name: "Auth not base64", data: `{"auths": {"quay.io": {"auth": "user:password"}}}`, wantError: true},
+		{name: "Auth without password separator", data: `{"auths": {"quay.io": {"auth": "dXNlcg=="}}}`, wantError: true},
+	}
+
+	for _, tc := range tests {
+		config, err := Parse([]byte(tc.data))
+		assert.Equal(t, tc.wantError, err != nil, tc.name)
+		if err == nil {
+			assert.Equal(t, tc.wantRegistries, config.Registries(), tc.name)
+		}
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: "docker.io"},
+		{image: "library/nginx:1.25", want: "docker.io"},
+		{image: "quay.io/testnetworkfunction/cnf-test-partner:latest", want: "quay.io"},
+		{image: "registry.local:5000/app@sha256:abcd", want: "registry.local:5000"},
+		{image: "localhost/app", want: "localhost"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, ImageRegistry(tc.image), tc.image)
+	}
+}
@@ -0,0 +1,105 @@
+// Package labels implements the CNF Certification Suite labels expression grammar, used to select
+// the test cases to be run. An expression is made of labels (test case ids, suite names or tags)
+// combined with the operators "&&", "||" and "!", and parentheses. A comma is an alias of "||".
+package labels
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Evaluator checks whether a list of labels matches a labels expression.
+type Evaluator interface {
+	Eval(labels []string) bool
+}
+
+type exprEvaluator struct {
+	astRootNode ast.Expr
+}
+
+// The certsuite evaluates the expressions as go expressions, so labels' dashes are replaced
+// by underscores to make them valid identifiers.
+func toGoIdentifier(label string) string {
+	return strings.ReplaceAll(label, "-", "_")
+}
+
+// NewEvaluator parses the labels expression, returning an error in case it doesn't follow the
+// CNF Certification Suite labels grammar.
+func NewEvaluator(labelsExpr string) (Evaluator, error) {
+	if strings.TrimSpace(labelsExpr) == "" {
+		return nil, fmt.Errorf("labels expression is empty")
+	}
+
+	goLikeExpr := toGoIdentifier(labelsExpr)
+	goLikeExpr = strings.ReplaceAll(goLikeExpr, ",", "||")
+
+	node, err := parser.ParseExpr(goLikeExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels expression %q: %w", labelsExpr, err)
+	}
+
+	err = checkNode(node)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels expression %q: %w", labelsExpr, err)
+	}
+
+	return exprEvaluator{astRootNode: node}, nil
+}
+
+// Checks that the expression only has labels, the supported operators and parentheses.
+func checkNode(node ast.Expr) error {
+	switch v := node.(type) {
+	case *ast.Ident:
+		return nil
+	case *ast.ParenExpr:
+		return checkNode(v.X)
+	case *ast.UnaryExpr:
+		if v.Op != token.NOT {
+			return fmt.Errorf("unsupported operator %q", v.Op)
+		}
+		return checkNode(v.X)
+	case *ast.BinaryExpr:
+		if v.Op != token.LAND && v.Op != token.LOR {
+			return fmt.Errorf("unsupported operator %q", v.Op)
+		}
+		if err := checkNode(v.X); err != nil {
+			return err
+		}
+		return checkNode(v.Y)
+	case *ast.BasicLit:
+		return fmt.Errorf("unexpected literal %s", v.Value)
+	default:
+		return fmt.Errorf("unexpected expression at position %d", node.Pos())
+	}
+}
+
+// Eval returns true if the labels match the expression.
+func (e exprEvaluator) Eval(labels []string) bool {
+	labelsMap := map[string]bool{}
+	for _, label := range labels {
+		labelsMap[toGoIdentifier(label)] = true
+	}
+
+	var visit func(node ast.Expr) bool
+	visit = func(node ast.Expr) bool {
+		switch v := node.(type) {
+		case *ast.Ident:
+			return labelsMap[v.Name]
+		case *ast.ParenExpr:
+			return visit(v.X)
+		case *ast.UnaryExpr:
+			return !visit(v.X)
+		case *ast.BinaryExpr:
+			if v.Op == token.LAND {
+				return visit(v.X) && visit(v.Y)
+			}
+			return visit(v.X) || visit(v.Y)
+		}
+		return false
+	}
+
+	return visit(e.astRootNode)
+}
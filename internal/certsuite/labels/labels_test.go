@@ -0,0 +1,55 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		labelExpr string
+		wantError bool
+	}{
+		{name: "Single label", labelExpr: "observability"},
+		{name: "Test case id", labelExpr: "access-control-sys-admin-capability-check"},
+		{name: "Operators and parentheses", labelExpr: "(common || extended) && !lifecycle-crd-scaling"},
+		{name: "Comma separated labels", labelExpr: "observability,networking"},
+		{name: "Empty expression", labelExpr: "  ", wantError: true},
+		{name: "Unbalanced parentheses", labelExpr: "(common || extended", wantError: true},
+		{name: "Unknown operator", labelExpr: "common | extended", wantError: true},
+		{name: "Comparison operator", labelExpr: "common == extended", wantError: true},
+		{name: "Missing operand", labelExpr: "common &&", wantError: true},
+		{name: "Literal", labelExpr: "\"common\"", wantError: true},
+		{name: "Function call", labelExpr: "common(extended)", wantError: true},
+	}
+
+	for _, tc := range tests {
+		_, err := NewEvaluator(tc.labelExpr)
+		assert.Equal(t, tc.wantError, err != nil, tc.name)
+	}
+}
+
+func TestEval(t *testing.T) {
+	labels := []string{"lifecycle-crd-scaling", "lifecycle", "common"}
+
+	tests := []struct {
+		labelExpr string
+		want      bool
+	}{
+		{labelExpr: "lifecycle", want: true},
+		{labelExpr: "lifecycle-crd-scaling", want: true},
+		{labelExpr: "observability", want: false},
+		{labelExpr: "observability,lifecycle", want: true},
+		{labelExpr: "common && !lifecycle-crd-scaling", want: false},
+		{labelExpr: "(observability || common) && lifecycle", want: true},
+		{labelExpr: "!(extended)", want: true},
+	}
+
+	for _, tc := range tests {
+		evaluator, err := NewEvaluator(tc.labelExpr)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.want, evaluator.Eval(labels), tc.labelExpr)
+	}
+}
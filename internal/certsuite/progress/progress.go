@@ -0,0 +1,99 @@
+// Package progress follows the progress of a CNF Certification Suite run from the lines of its log,
+// where the certsuite records when every test case starts and its result.
+package progress
+
+import (
+	"regexp"
+	"strings"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/labels"
+)
+
+// LogFileName is the name of the certsuite's log file, in its output folder.
+const LogFileName = "certsuite.log"
+
+// The certsuite logs the test case id between brackets, before the message, e.g.:
+// INFO  [Feb 15 13:05:50.749] [check.go: 263] [observability-crd-status] Running check (labels: [...])
+// INFO  [Feb 15 13:05:50.749] [checksdb.go: 115] [observability-crd-status] Recording result "PASSED", claimID: {...}
+var (
+	runningCheckRegex    = regexp.MustCompile(`\[([^\]\s]+)\] Running check`)
+	recordingResultRegex = regexp.MustCompile(`\[([^\]\s]+)\] Recording result "(\w+)"`)
+)
+
+// Tracker holds the progress of a run, updated with every line of the certsuite's log.
+type Tracker struct {
+	progress cnfcertificationsv1alpha1.RunProgress
+	// Test cases whose result was recorded, so they're not counted twice.
+	recorded map[string]bool
+	changed  bool
+}
+
+// NewTracker returns a tracker for a run with the given labels filter. The total number of test
+// cases is the number of test cases of the catalog matched by the filter, so it's an estimate
+// when the certsuite image's version is not the one of the catalog.
+func NewTracker(labelsFilter string) *Tracker {
+	total := 0
+	if evaluator, err := labels.NewEvaluator(labelsFilter); err == nil {
+		total = len(catalog.MatchingTestCases(evaluator.Eval))
+	}
+
+	return &Tracker{
+		progress: cnfcertificationsv1alpha1.RunProgress{TotalTests: total},
+		recorded: map[string]bool{},
+		changed:  true,
+	}
+}
+
+// ParseLine updates the progress with a line of the certsuite's log.
+func (t *Tracker) ParseLine(line string) {
+	if match := recordingResultRegex.FindStringSubmatch(line); match != nil {
+		t.recordResult(match[1], strings.ToLower(match[2]))
+		return
+	}
+
+	if match := runningCheckRegex.FindStringSubmatch(line); match != nil && match[1] != t.progress.CurrentTest {
+		t.progress.CurrentTest = match[1]
+		t.changed = true
+	}
+}
+
+func (t *Tracker) recordResult(testCaseName, result string) {
+	if t.recorded[testCaseName] {
+		return
+	}
+	t.recorded[testCaseName] = true
+
+	progress := &t.progress
+	switch result {
+	case cnfcertificationsv1alpha1.StatusStatePassed:
+		progress.Passed++
+	case cnfcertificationsv1alpha1.StatusStateSkipped:
+		progress.Skipped++
+	case cnfcertificationsv1alpha1.StatusStateFailed:
+		progress.Failed++
+	default:
+		progress.Errored++
+	}
+
+	progress.CompletedTests++
+	if progress.CompletedTests > progress.TotalTests {
+		progress.TotalTests = progress.CompletedTests
+	}
+	if progress.CurrentTest == testCaseName {
+		progress.CurrentTest = ""
+	}
+	t.changed = true
+}
+
+// Changed returns true if the progress changed since the last call to Progress.
+func (t *Tracker) Changed() bool {
+	return t.changed
+}
+
+// Progress returns a copy of the current progress.
+func (t *Tracker) Progress() cnfcertificationsv1alpha1.RunProgress {
+	t.changed = false
+	return t.progress
+}
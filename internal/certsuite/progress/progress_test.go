@@ -0,0 +1,53 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker("observability")
+	total := len(catalog.MatchingTestCases(func(labels []string) bool { return labels[1] == "observability" }))
+	assert.True(t, tracker.Changed())
+	progress := tracker.Progress()
+	assert.Equal(t, total, progress.TotalTests)
+	assert.False(t, tracker.Changed())
+
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.749] [check.go: 263] [observability-crd-status] Running check (labels: [common observability-crd-status observability])`)
+	assert.True(t, tracker.Changed())
+	progress = tracker.Progress()
+	assert.Equal(t, "observability-crd-status", progress.CurrentTest)
+	assert.Equal(t, 0, progress.CompletedTests)
+
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.750] [checksdb.go: 115] [observability-crd-status] Recording result "PASSED", claimID: {Id:observability-crd-status Suite:observability Tags:common}`)
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.751] [suite.go: 193] [observability-pod-disruption-budget] Testing Deployment "deployment: test ns: tnf"`)
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.752] [checksdb.go: 115] [observability-pod-disruption-budget] Recording result "FAILED", claimID: {}`)
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.753] [checksdb.go: 115] [observability-container-logging] Recording result "SKIPPED", claimID: {}`)
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.754] [checksdb.go: 115] [observability-termination-policy] Recording result "ERROR", claimID: {}`)
+	// Results recorded twice are counted once.
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.755] [checksdb.go: 115] [observability-crd-status] Recording result "PASSED", claimID: {}`)
+
+	progress = tracker.Progress()
+	assert.Equal(t, 4, progress.CompletedTests)
+	assert.Equal(t, 1, progress.Passed)
+	assert.Equal(t, 1, progress.Failed)
+	assert.Equal(t, 1, progress.Skipped)
+	assert.Equal(t, 1, progress.Errored)
+	assert.Empty(t, progress.CurrentTest)
+
+	tracker.ParseLine("unrelated line")
+	assert.False(t, tracker.Changed())
+}
+
+func TestTrackerUnknownTotal(t *testing.T) {
+	tracker := NewTracker("")
+	tracker.ParseLine(`INFO  [Feb 15 13:05:50.752] [checksdb.go: 115] [observability-crd-status] Recording result "PASSED", claimID: {}`)
+
+	// The total grows with the completed test cases when the filter's test cases are unknown.
+	progress := tracker.Progress()
+	assert.Equal(t, 1, progress.TotalTests)
+	assert.Equal(t, 1, progress.CompletedTests)
+}
@@ -0,0 +1,120 @@
+// Package collector pushes the results of a run to a collector, using the same protocol as the
+// certsuite's --enable-data-collection: a multipart form posted to the collector's endpoint.
+package collector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/delivery"
+)
+
+// Fields of the multipart form posted to the collector.
+const (
+	ClaimFileField   = "claimFile"
+	ReportFileField  = "reportFile"
+	ExecutedByField  = "executed_by"
+	PartnerNameField = "partner_name"
+	PasswordField    = "decoded_password"
+)
+
+// Keys of the secret with the collector's credentials.
+const (
+	ExecutedBySecretKey  = "executedBy"
+	PartnerNameSecretKey = "partnerName"
+	PasswordSecretKey    = "password"
+)
+
+const (
+	// Names of the files posted to the collector.
+	claimFileName  = "claim.json"
+	reportFileName = "report.json"
+	// Timeout of every attempt to push the results.
+	sendTimeout = 60 * time.Second
+)
+
+// Credentials holds the values the collector uses to identify who pushed the results.
+type Credentials struct {
+	ExecutedBy  string
+	PartnerName string
+	Password    string
+}
+
+// Results holds the run's results pushed to the collector.
+type Results struct {
+	Claim []byte
+	// Report holds the run CR's report as json. It's optional, as the certsuite's collector only
+	// expects the claim file.
+	Report []byte
+}
+
+func newRequestBody(results *Results, credentials *Credentials) (body *bytes.Buffer, contentType string, err error) {
+	body = &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	files := []struct{ field, name string }{{ClaimFileField, claimFileName}, {ReportFileField, reportFileName}}
+	for i, data := range [][]byte{results.Claim, results.Report} {
+		if data == nil {
+			continue
+		}
+		fw, err := w.CreateFormFile(files[i].field, files[i].name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err = fw.Write(data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for field, value := range map[string]string{
+		ExecutedByField:  credentials.ExecutedBy,
+		PartnerNameField: credentials.PartnerName,
+		PasswordField:    credentials.Password,
+	} {
+		if err = w.WriteField(field, value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, w.FormDataContentType(), nil
+}
+
+// Send posts the results to the collector's endpoint once.
+func Send(ctx context.Context, endpoint string, results *Results, credentials *Credentials) error {
+	body, contentType, err := newRequestBody(results, credentials)
+	if err != nil {
+		return fmt.Errorf("failed to create request body: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post results: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return delivery.CheckResponse("collector", resp)
+}
+
+// Deliver posts the results, retrying with an exponential backoff until it succeeds or the max
+// number of attempts is reached. Returns the number of attempts and the last error.
+func Deliver(ctx context.Context, endpoint string, results *Results, credentials *Credentials, maxAttempts int, backoff time.Duration) (attempts int, err error) {
+	return delivery.Retry(ctx, maxAttempts, backoff, func(ctx context.Context) error {
+		return Send(ctx, endpoint, results, credentials)
+	})
+}
@@ -0,0 +1,69 @@
+package collector_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/collector"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/test/fakecollector"
+)
+
+var (
+	results     = collector.Results{Claim: []byte(`{"claim":{}}`), Report: []byte(`{"verdict":"pass"}`)}
+	credentials = collector.Credentials{ExecutedBy: "ci", PartnerName: "acme", Password: "secret"}
+)
+
+func TestSend(t *testing.T) {
+	server, fake := fakecollector.NewServer()
+	defer server.Close()
+	fake.RequirePassword("secret")
+
+	err := collector.Send(context.TODO(), server.URL, &results, &credentials)
+	assert.Nil(t, err)
+	assert.Equal(t, []fakecollector.Upload{{
+		Claim:       results.Claim,
+		Report:      results.Report,
+		ExecutedBy:  "ci",
+		PartnerName: "acme",
+		Password:    "secret",
+	}}, fake.Uploads())
+
+	// The report is optional.
+	err = collector.Send(context.TODO(), server.URL, &collector.Results{Claim: results.Claim}, &credentials)
+	assert.Nil(t, err)
+	assert.Len(t, fake.Uploads(), 2)
+	assert.Nil(t, fake.Uploads()[1].Report)
+
+	// The collector's response is included in the error.
+	err = collector.Send(context.TODO(), server.URL, &results, &collector.Credentials{Password: "wrong"})
+	assert.EqualError(t, err, "collector returned 401 Unauthorized: invalid password")
+	assert.Len(t, fake.Uploads(), 2)
+}
+
+func TestDeliver(t *testing.T) {
+	server, fake := fakecollector.NewServer()
+	defer server.Close()
+
+	// Delivered after retrying.
+	fake.FailNext(2)
+	attempts, err := collector.Deliver(context.TODO(), server.URL, &results, &credentials, 3, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, fake.Uploads(), 1)
+
+	// Gives up after the max attempts.
+	fake.FailNext(2)
+	attempts, err = collector.Deliver(context.TODO(), server.URL, &results, &credentials, 2, time.Millisecond)
+	assert.EqualError(t, err, "collector returned 500 Internal Server Error: collector unavailable")
+	assert.Equal(t, 2, attempts)
+	assert.Len(t, fake.Uploads(), 1)
+
+	// Unreachable collector.
+	server.Close()
+	attempts, err = collector.Deliver(context.TODO(), server.URL, &results, &credentials, 1, time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+const (
+	certSuiteConfigKey             = "tnf_config.yaml"
+	defaultProbeDaemonSetNamespace = "cnf-suite"
+)
+
+// Returns the CNF Cert Suite config of a run CR: its spec's config if set, or the one parsed from
+// its config map otherwise.
+func (r *CnfCertificationSuiteRunReconciler) getRunCertSuiteConfig(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) (*cnfcertificationsv1alpha1.CnfCertSuiteConfig, error) {
+	if runCR.Spec.Config != nil {
+		return runCR.Spec.Config, nil
+	}
+
+	configMap := corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: runCR.Spec.ConfigMapName, Namespace: runCR.Namespace}, &configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s (ns %s): %w", runCR.Spec.ConfigMapName, runCR.Namespace, err)
+	}
+
+	config := cnfcertificationsv1alpha1.CnfCertSuiteConfig{}
+	err = yaml.Unmarshal([]byte(configMap.Data[certSuiteConfigKey]), &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s of config map %s (ns %s): %w", certSuiteConfigKey, configMap.Name, configMap.Namespace, err)
+	}
+
+	return &config, nil
+}
+
+// Renders the CNF Cert Suite config into the content of a config map.
+func renderCertSuiteConfig(config *cnfcertificationsv1alpha1.CnfCertSuiteConfig) (map[string]string, error) {
+	configYaml, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render cnf cert suite config: %w", err)
+	}
+
+	return map[string]string{certSuiteConfigKey: string(configYaml)}, nil
+}
+
+// Returns the names of the target namespaces of the config.
+func getTargetNamespaces(config *cnfcertificationsv1alpha1.CnfCertSuiteConfig) []string {
+	namespaces := []string{}
+	for _, ns := range config.TargetNameSpaces {
+		namespaces = append(namespaces, string(ns.Name))
+	}
+	return namespaces
+}
+
+// Returns the namespace where the CNF Cert Suite deploys its probe daemonset.
+func getProbeNamespace(config *cnfcertificationsv1alpha1.CnfCertSuiteConfig) string {
+	if config.ProbeDaemonSetNamespace == "" {
+		return defaultProbeDaemonSetNamespace
+	}
+	return string(config.ProbeDaemonSetNamespace)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/artifacts"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+const (
+	// Bounds of the CNF Cert Suite container's output tail set in the run CR's status.
+	certSuiteLogsTailLines   = 50
+	certSuiteLogsTailMaxSize = 4 * 1024
+)
+
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=configMaps,verbs=update
+
+// Returns the last lines of the logs, up to certSuiteLogsTailLines lines and
+// certSuiteLogsTailMaxSize bytes.
+func getLogsTail(logs string) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	if len(lines) > certSuiteLogsTailLines {
+		lines = lines[len(lines)-certSuiteLogsTailLines:]
+	}
+
+	tail := strings.Join(lines, "\n")
+	if len(tail) > certSuiteLogsTailMaxSize {
+		tail = tail[len(tail)-certSuiteLogsTailMaxSize:]
+		// Drop the first line, as it's cut.
+		if i := strings.Index(tail, "\n"); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	return tail
+}
+
+// Stores the output of the finished CNF Cert Suite container in the run's artifacts config map, so
+// it's kept after the pod is removed. If setTail is true, its last lines are also set in the run
+// CR's status, for quick diagnosis.
+func (r *CnfCertificationSuiteRunReconciler) captureCertSuiteLogs(ctx context.Context, runCrNamespacedName, certSuitePodNamespacedName types.NamespacedName, setTail bool) {
+	logs, err := r.KubeClient.CoreV1().Pods(certSuitePodNamespacedName.Namespace).GetLogs(certSuitePodNamespacedName.Name,
+		&corev1.PodLogOptions{Container: definitions.CnfCertSuiteContainerName}).DoRaw(ctx)
+	if err != nil {
+		logger.Errorf("Failed to get the logs of the CNF Cert Suite container of pod %s: %v", certSuitePodNamespacedName, err)
+		return
+	}
+
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	err = r.Get(ctx, runCrNamespacedName, &runCR)
+	if err != nil {
+		logger.Errorf("Failed to get CR %s to store its CNF Cert Suite container logs: %v", runCrNamespacedName, err)
+		return
+	}
+
+	configMapName, dropped, err := artifacts.Store(ctx, r.Client, &runCR, map[string][]byte{artifacts.CertSuiteContainerLogKey: artifacts.TruncateLog(logs)})
+	if err != nil {
+		logger.Errorf("Failed to store the CNF Cert Suite container logs of CR %s: %v", runCrNamespacedName, err)
+	} else if len(dropped) > 0 {
+		logger.Errorf("The CNF Cert Suite container logs of CR %s are too big to be stored in config map %s.", runCrNamespacedName, configMapName)
+	}
+
+	err = r.updateStatus(runCrNamespacedName, func(status *cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus) {
+		if configMapName != "" {
+			status.ArtifactsConfigMap = configMapName
+		}
+		for _, key := range dropped {
+			if !slices.Contains(status.DroppedArtifacts, key) {
+				status.DroppedArtifacts = append(status.DroppedArtifacts, key)
+			}
+		}
+		if setTail {
+			status.CertSuiteLogsTail = getLogsTail(string(logs))
+		}
+	})
+	if err != nil {
+		logger.Errorf("Failed to set the CNF Cert Suite container logs in CR %s: %v", runCrNamespacedName, err)
+	}
+}
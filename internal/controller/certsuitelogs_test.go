@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/artifacts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_getLogsTail(t *testing.T) {
+	assert.Equal(t, "line 1\nline 2", getLogsTail("line 1\nline 2\n"))
+
+	lines := []string{}
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	tail := getLogsTail(strings.Join(lines, "\n"))
+	assert.True(t, strings.HasPrefix(tail, "line 51\n"))
+	assert.True(t, strings.HasSuffix(tail, "\nline 100"))
+
+	// Long lines are bounded by size, without the first cut line.
+	longLine := strings.Repeat("a", 1000)
+	tail = getLogsTail(strings.Repeat(longLine+"\n", 10))
+	assert.LessOrEqual(t, len(tail), certSuiteLogsTailMaxSize)
+	assert.Equal(t, strings.Repeat(longLine+"\n", 3)+longLine, tail)
+}
+
+func TestCnfCertificationSuiteRunReconciler_captureCertSuiteLogs(t *testing.T) {
+	runCrNamespacedName := types.NamespacedName{Name: "cnf-run", Namespace: "cnf-ns"}
+	podNamespacedName := types.NamespacedName{Name: "cnf-job-run-1", Namespace: "cnf-ns"}
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: runCrNamespacedName.Name, Namespace: runCrNamespacedName.Namespace},
+		Status:     cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{Phase: cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning},
+	}
+
+	r := mockReconciler([]runtime.Object{runCR})
+	r.captureCertSuiteLogs(context.TODO(), runCrNamespacedName, podNamespacedName, true)
+
+	updatedRunCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	assert.Nil(t, r.Get(context.TODO(), runCrNamespacedName, &updatedRunCR))
+	assert.Equal(t, artifacts.ConfigMapName("cnf-run"), updatedRunCR.Status.ArtifactsConfigMap)
+	// The fake clientset returns "fake logs" as the logs of every container.
+	assert.Equal(t, "fake logs", updatedRunCR.Status.CertSuiteLogsTail)
+
+	configMap := corev1.ConfigMap{}
+	assert.Nil(t, r.Get(context.TODO(), types.NamespacedName{Name: updatedRunCR.Status.ArtifactsConfigMap, Namespace: "cnf-ns"}, &configMap))
+	logs, err := artifacts.Load(&configMap, artifacts.CertSuiteContainerLogKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake logs", string(logs))
+}
@@ -2,21 +2,17 @@ package cnfcertjob
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
 )
 
-const (
-	// Be careful when changing this SA name.
-	// 1. It must match the flag --extra-service-accounts in "make bundle".
-	// 2. The prefix is "cnf-certsuite-". It should match the field namePrefix field in config/default/kustomization.yaml.
-	clusterAccessServiceAccountName = "cnf-certsuite-cluster-access"
-)
-
 func New(options ...func(*corev1.Pod) error) (*corev1.Pod, error) {
 	jobPod := newInitialJobPod()
 
@@ -35,7 +31,7 @@ func newInitialJobPod() *corev1.Pod {
 		TypeMeta:   metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{},
 		Spec: corev1.PodSpec{
-			ServiceAccountName: clusterAccessServiceAccountName,
+			ServiceAccountName: definitions.JobServiceAccountName,
 			RestartPolicy:      "Never",
 			Containers: []corev1.Container{
 				{
@@ -121,6 +117,13 @@ func WithNamespace(namespace string) func(*corev1.Pod) error {
 	}
 }
 
+func WithServiceAccountName(serviceAccountName string) func(*corev1.Pod) error {
+	return func(p *corev1.Pod) error {
+		p.Spec.ServiceAccountName = serviceAccountName
+		return nil
+	}
+}
+
 func WithCertSuiteConfigRunName(certSuiteConfigRunName string) func(*corev1.Pod) error {
 	return func(p *corev1.Pod) error {
 		envVar := corev1.EnvVar{Name: "RUN_CR_NAME", Value: certSuiteConfigRunName}
@@ -133,6 +136,29 @@ func WithCertSuiteConfigRunName(certSuiteConfigRunName string) func(*corev1.Pod)
 	}
 }
 
+func WithCertSuiteConfigRunNamespace(certSuiteConfigRunNamespace string) func(*corev1.Pod) error {
+	return func(p *corev1.Pod) error {
+		envVar := corev1.EnvVar{Name: "RUN_CR_NAMESPACE", Value: certSuiteConfigRunNamespace}
+		sideCarContainer := getSideCarAppContainer(p)
+		if sideCarContainer == nil {
+			return fmt.Errorf("side Car app Container is not found in pod %s", p.Name)
+		}
+		sideCarContainer.Env = append(sideCarContainer.Env, envVar)
+		return nil
+	}
+}
+
+func WithRunLabels(runCrName, runCrNamespace string) func(*corev1.Pod) error {
+	return func(p *corev1.Pod) error {
+		if p.ObjectMeta.Labels == nil {
+			p.ObjectMeta.Labels = map[string]string{}
+		}
+		p.ObjectMeta.Labels[definitions.RunCrNameLabel] = runCrName
+		p.ObjectMeta.Labels[definitions.RunCrNamespaceLabel] = runCrNamespace
+		return nil
+	}
+}
+
 func WithLabelsFilter(labelsFilter string) func(*corev1.Pod) error {
 	return func(p *corev1.Pod) error {
 		cnfCertSuiteContainer := getCnfCertSuiteContainer(p)
@@ -183,7 +209,9 @@ func WithConfigMap(configMapName string) func(*corev1.Pod) error {
 	}
 }
 
-func WithPreflightSecret(preflightSecretName *string) func(*corev1.Pod) error {
+// Mounts the preflight secret's docker config json, set in the secretKey, in the path expected by
+// the CNF Cert Suite container.
+func WithPreflightSecret(preflightSecretName *string, secretKey string) func(*corev1.Pod) error {
 	return func(p *corev1.Pod) error {
 		if preflightSecretName == nil {
 			return nil
@@ -194,6 +222,10 @@ func WithPreflightSecret(preflightSecretName *string) func(*corev1.Pod) error {
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					SecretName: *preflightSecretName,
+					Items: []corev1.KeyToPath{{
+						Key:  secretKey,
+						Path: filepath.Base(definitions.PreflightDockerConfigFilePath),
+					}},
 				},
 			},
 		}
@@ -210,6 +242,22 @@ func WithPreflightSecret(preflightSecretName *string) func(*corev1.Pod) error {
 	}
 }
 
+// Sets the CNF Cert Suite container's image. If empty, the default image is kept.
+func WithCertSuiteImage(certSuiteImage string) func(*corev1.Pod) error {
+	return func(p *corev1.Pod) error {
+		if certSuiteImage == "" {
+			return nil
+		}
+
+		cnfCertSuiteContainer := getCnfCertSuiteContainer(p)
+		if cnfCertSuiteContainer == nil {
+			return fmt.Errorf("cnf cert suite Container is not found in pod %s", p.Name)
+		}
+		cnfCertSuiteContainer.Image = certSuiteImage
+		return nil
+	}
+}
+
 func WithSideCarApp(sideCarAppImage string) func(*corev1.Pod) error {
 	return func(p *corev1.Pod) error {
 		sideCarContainer := getSideCarAppContainer(p)
@@ -232,8 +280,73 @@ func WithEnableDataCollection(enableDataCollection string) func(*corev1.Pod) err
 	}
 }
 
-func WithOwnerReference(ownerUID types.UID, ownerName, ownerKind, ownerAPIVersion string) func(*corev1.Pod) error {
+// Mounts the offline certification database in the CNF Cert Suite container, and makes the
+// certsuite use it. Databases from an image are copied to an empty dir volume by an init container.
+func WithOfflineDB(offlineDB *cnfcertificationsv1alpha1.OfflineDB) func(*corev1.Pod) error {
+	return func(p *corev1.Pod) error {
+		if offlineDB == nil {
+			return nil
+		}
+
+		cnfCertSuiteContainer := getCnfCertSuiteContainer(p)
+		if cnfCertSuiteContainer == nil {
+			return fmt.Errorf("cnf cert suite Container is not found in pod %s", p.Name)
+		}
+
+		volume := corev1.Volume{Name: "cnf-certsuite-offline-db"}
+		volumeMount := corev1.VolumeMount{
+			Name:      "cnf-certsuite-offline-db",
+			ReadOnly:  true,
+			MountPath: definitions.CnfCertSuiteOfflineDBFolder,
+		}
+
+		switch {
+		case offlineDB.PersistentVolumeClaim != nil:
+			volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: offlineDB.PersistentVolumeClaim.ClaimName,
+				ReadOnly:  true,
+			}
+			volumeMount.SubPath = strings.Trim(offlineDB.PersistentVolumeClaim.SubPath, "/")
+		case offlineDB.ConfigMap != nil:
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: offlineDB.ConfigMap.Name},
+				Items:                offlineDB.ConfigMap.Items,
+			}
+		case offlineDB.Image != nil:
+			imagePath := offlineDB.Image.Path
+			if imagePath == "" {
+				imagePath = definitions.DefaultOfflineDBImagePath
+			}
+			volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+			p.Spec.InitContainers = append(p.Spec.InitContainers, corev1.Container{
+				Name:            definitions.OfflineDBInitContainerName,
+				Image:           offlineDB.Image.Image,
+				Command:         []string{"cp", "-r", strings.TrimSuffix(imagePath, "/") + "/.", definitions.CnfCertSuiteOfflineDBFolder},
+				ImagePullPolicy: "IfNotPresent",
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      volume.Name,
+					MountPath: definitions.CnfCertSuiteOfflineDBFolder,
+				}},
+			})
+		default:
+			return fmt.Errorf("offline db of pod %s has no source", p.Name)
+		}
+
+		p.Spec.Volumes = append(p.Spec.Volumes, volume)
+		cnfCertSuiteContainer.VolumeMounts = append(cnfCertSuiteContainer.VolumeMounts, volumeMount)
+		cnfCertSuiteContainer.Args = append(cnfCertSuiteContainer.Args, "--offline-db", definitions.CnfCertSuiteOfflineDBFolder)
+		return nil
+	}
+}
+
+// Sets the run CR as owner of the pod. Owner references can't point to objects in other
+// namespaces, so it's not set when the pod is running in a different namespace than the CR's one.
+func WithOwnerReference(ownerUID types.UID, ownerName, ownerNamespace, ownerKind, ownerAPIVersion string) func(*corev1.Pod) error {
 	return func(p *corev1.Pod) error {
+		if ownerNamespace != p.ObjectMeta.Namespace {
+			return nil
+		}
+
 		ownerReference := &metav1.OwnerReference{
 			APIVersion: ownerAPIVersion,
 			Kind:       ownerKind,
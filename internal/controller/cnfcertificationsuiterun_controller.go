@@ -25,9 +25,10 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,19 +41,36 @@ import (
 	cnfcertjob "github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/cnf-cert-job"
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
 	controllerlogger "github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/logger"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/metrics"
 )
 
-var sideCarImage string
+var (
+	sideCarImage string
+	// executionNamespace holds the namespace where all the CNF Cert job pods are created.
+	// If empty, job pods are created in the run CR's namespace.
+	executionNamespace string
+	// generateRunRbac is set to true to generate a service account with a scoped RBAC for every run.
+	generateRunRbac bool
+	// maxConcurrentRuns holds the max number of runs that can be active at the same time. Zero means no limit.
+	maxConcurrentRuns int
+	// deleteCompletedJobPods is set to true to remove the job pods once their run has finished.
+	deleteCompletedJobPods bool
+)
 
 // CnfCertificationSuiteRunReconciler reconciles a CnfCertificationSuiteRun object
 type CnfCertificationSuiteRunReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	// APIReader reads objects directly from the API server, for objects out of the watched namespaces.
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	// Recorder records the events of the run lifecycle in the CnfCertificationSuiteRuns.
+	Recorder record.EventRecorder
+	// KubeClient is used for the requests not supported by the controller-runtime client, like
+	// getting the pods' logs.
+	KubeClient kubernetes.Interface
 }
 
 var (
-	// certificationRuns maps a certificationRun to a pod name
-	certificationRuns map[types.NamespacedName]string = map[types.NamespacedName]string{}
 	// Holds an autoincremental CNF Cert Suite pod id
 	certSuitePodID int
 	// sets controller's logger.
@@ -64,22 +82,30 @@ const (
 	defaultCnfCertSuiteTimeout = time.Hour
 )
 
-// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,namespace=cnf-certsuite-operator,resources=cnfcertificationsuiteruns,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,namespace=cnf-certsuite-operator,resources=cnfcertificationsuiteruns/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,namespace=cnf-certsuite-operator,resources=cnfcertificationsuiteruns/finalizers,verbs=update
+// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,resources=cnfcertificationsuiteruns/finalizers,verbs=update
 
-// +kubebuilder:rbac:groups="",namespace=cnf-certsuite-operator,resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",namespace=cnf-certsuite-operator,resources=secrets;configMaps,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",namespace=cnf-certsuite-operator,resources=namespaces;services;configMaps,verbs=create
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups="",resources=secrets;configMaps,verbs=get;list;watch;update;delete;deletecollection
+// +kubebuilder:rbac:groups="",resources=namespaces;services;configMaps;secrets,verbs=create
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings;rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=bind,resourceNames=cnf-certsuite-run-cluster-reader;cnf-certsuite-run-namespace-tester;cnf-certsuite-run-probe-namespace;cnf-certsuite-run-sidecar
+
+// +kubebuilder:rbac:groups="authorization.k8s.io",resources=subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // +kubebuilder:rbac:groups="console.openshift.io",resources=consoleplugins,verbs=create
-// +kubebuilder:rbac:groups="apps",namespace=cnf-certsuite-operator,resources=deployments,verbs=create
+// +kubebuilder:rbac:groups="apps",resources=deployments,verbs=create
 
 func ignoreUpdatePredicate() predicate.Predicate {
 	return predicate.Funcs{
-		UpdateFunc: func(_ event.UpdateEvent) bool {
-			// Ignore updates to CR
-			return false
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Ignore updates to CR, except the ones marking it for deletion, so it can be finalized.
+			return !e.ObjectNew.GetDeletionTimestamp().IsZero()
 		},
 	}
 }
@@ -135,6 +161,7 @@ func getJobRunTimeThreshold(timeoutStr string) time.Duration {
 }
 
 func (r *CnfCertificationSuiteRunReconciler) waitForCertSuitePodToComplete(certSuitePodNamespacedName types.NamespacedName, timeOut time.Duration) (exitStatusCode int32, err error) {
+	unschedulableReported := false
 	for startTime := time.Now(); time.Since(startTime) < timeOut; {
 		certSuitePod := corev1.Pod{}
 		err = r.Get(context.TODO(), certSuitePodNamespacedName, &certSuitePod)
@@ -148,6 +175,11 @@ func (r *CnfCertificationSuiteRunReconciler) waitForCertSuitePodToComplete(certS
 			return 0, nil
 		case corev1.PodFailed:
 			logger.Info("Cnf job pod has completed with failure.")
+			if exitStatus, err := getOfflineDBInitContainerExitStatus(&certSuitePod); err != nil {
+				r.recordRunEvent(getPodRunCrNamespacedName(&certSuitePod), corev1.EventTypeWarning, eventReasonOfflineDBFailed,
+					"CNF Cert job pod %s: %v", certSuitePodNamespacedName.Name, err)
+				return exitStatus, err
+			}
 			exitStatus, err := getCertSuiteContainerExitStatus(&certSuitePod)
 			if err != nil {
 				return 0, err
@@ -155,6 +187,11 @@ func (r *CnfCertificationSuiteRunReconciler) waitForCertSuitePodToComplete(certS
 			return exitStatus, nil
 		default:
 			logger.Infof("Cnf job pod is running. Current status: %s", certSuitePod.Status.Phase)
+			if message, unschedulable := getPodUnschedulableMessage(&certSuitePod); unschedulable && !unschedulableReported {
+				r.recordRunEvent(getPodRunCrNamespacedName(&certSuitePod), corev1.EventTypeWarning, eventReasonPodUnschedulable,
+					"CNF Cert job pod %s can't be scheduled: %s", certSuitePodNamespacedName.Name, message)
+				unschedulableReported = true
+			}
 			time.Sleep(checkInterval)
 		}
 	}
@@ -162,10 +199,21 @@ func (r *CnfCertificationSuiteRunReconciler) waitForCertSuitePodToComplete(certS
 	return 0, fmt.Errorf("timeout (%s) reached while waiting for cert suite pod %v to finish", timeOut, certSuitePodNamespacedName)
 }
 
+// Returns the namespaced name of the run CR of a cert suite pod, from its labels.
+func getPodRunCrNamespacedName(certSuitePod *corev1.Pod) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      certSuitePod.Labels[definitions.RunCrNameLabel],
+		Namespace: certSuitePod.Labels[definitions.RunCrNamespaceLabel],
+	}
+}
+
 func getCertSuiteContainerExitStatus(certSuitePod *corev1.Pod) (int32, error) {
 	for i := range certSuitePod.Status.ContainerStatuses {
 		containerStatus := &certSuitePod.Status.ContainerStatuses[i]
 		if containerStatus.Name == definitions.CnfCertSuiteContainerName {
+			if containerStatus.State.Terminated == nil {
+				return 0, fmt.Errorf("failed to get cert suite exit status: container didn't run in pod %s (ns %s)", certSuitePod.Name, certSuitePod.Namespace)
+			}
 			return containerStatus.State.Terminated.ExitCode, nil
 		}
 	}
@@ -173,28 +221,110 @@ func getCertSuiteContainerExitStatus(certSuitePod *corev1.Pod) (int32, error) {
 	return 0, fmt.Errorf("failed to get cert suite exit status: container not found in pod %s (ns %s)", certSuitePod.Name, certSuitePod.Namespace)
 }
 
-func (r *CnfCertificationSuiteRunReconciler) handleEndOfCnfCertSuiteRun(runCrName, certSuitePodName, namespace, reqTimeout string) {
-	certSuitePodNamespacedName := types.NamespacedName{Name: certSuitePodName, Namespace: namespace}
-	runCrNamespacedName := types.NamespacedName{Name: runCrName, Namespace: namespace}
+// Returns true if the sidecar container of the completed cert suite pod exited with an error, so
+// the results could not be published in the run CR's status.
+func (r *CnfCertificationSuiteRunReconciler) isSideCarPublishFailed(certSuitePodNamespacedName types.NamespacedName) (bool, error) {
+	certSuitePod := corev1.Pod{}
+	err := r.Get(context.TODO(), certSuitePodNamespacedName, &certSuitePod)
+	if err != nil {
+		return false, err
+	}
 
+	for i := range certSuitePod.Status.ContainerStatuses {
+		containerStatus := &certSuitePod.Status.ContainerStatuses[i]
+		if containerStatus.Name == definitions.CnfCertSuiteSidecarContainerName {
+			return containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0, nil
+		}
+	}
+
+	return false, fmt.Errorf("sidecar container not found in pod %s (ns %s)", certSuitePod.Name, certSuitePod.Namespace)
+}
+
+func (r *CnfCertificationSuiteRunReconciler) handleEndOfCnfCertSuiteRun(runCrNamespacedName, certSuitePodNamespacedName types.NamespacedName, reqTimeout string) {
+	startTime := time.Now()
 	certSuiteTimeout := getJobRunTimeThreshold(reqTimeout)
 	certSuiteExitStatusCode, err := r.waitForCertSuitePodToComplete(certSuitePodNamespacedName, certSuiteTimeout)
 	if err != nil {
 		logger.Errorf("failed to handle end of cert suite run: %v", err)
+		if time.Since(startTime) >= certSuiteTimeout {
+			r.recordRunEvent(runCrNamespacedName, corev1.EventTypeWarning, eventReasonTimeout,
+				"CNF Cert job pod %s didn't finish before the run's timeout (%s)", certSuitePodNamespacedName.Name, certSuiteTimeout)
+		}
+	} else {
+		publishFailed, checkErr := r.isSideCarPublishFailed(certSuitePodNamespacedName)
+		if checkErr != nil {
+			logger.Errorf("Failed to check the sidecar's exit status of CR %s: %v", runCrNamespacedName, checkErr)
+		} else if publishFailed {
+			logger.Errorf("The sidecar failed to publish the results of CR %s.", runCrNamespacedName)
+			metrics.SidecarPublishFailures.WithLabelValues(runCrNamespacedName.Namespace).Inc()
+			r.recordRunEvent(runCrNamespacedName, corev1.EventTypeWarning, eventReasonSidecarPublishFailed,
+				"Sidecar container of pod %s exited with an error, results may not have been published", certSuitePodNamespacedName.Name)
+		}
 	}
 
 	// cnf-cert-job has terminated - checking exit status of cert suite
+	phase := cnfcertificationsv1alpha1.StatusPhase(definitions.CnfCertificationSuiteRunStatusPhaseJobFinished)
 	if certSuiteExitStatusCode == 0 {
 		logger.Info("CNF Cert job has finished running.")
-		err = r.updateStatusPhase(runCrNamespacedName, definitions.CnfCertificationSuiteRunStatusPhaseJobFinished)
+		if err == nil {
+			r.recordRunEvent(runCrNamespacedName, corev1.EventTypeNormal, eventReasonSuiteFinished,
+				"CNF Certification Suite finished in %s", time.Since(startTime).Round(time.Second))
+		}
 	} else {
 		logger.Info("CNF Cert job encountered an error. Exit status: ", certSuiteExitStatusCode)
-		err = r.updateStatusPhase(runCrNamespacedName, definitions.CnfCertificationSuiteRunStatusPhaseJobError)
+		phase = definitions.CnfCertificationSuiteRunStatusPhaseJobError
+		if err == nil {
+			r.recordRunEvent(runCrNamespacedName, corev1.EventTypeWarning, eventReasonSuiteFailed,
+				"CNF Certification Suite container exited with code %d", certSuiteExitStatusCode)
+		}
 	}
 
+	metrics.RunDuration.WithLabelValues(string(phase)).Observe(time.Since(startTime).Seconds())
+	r.captureCertSuiteLogs(context.TODO(), runCrNamespacedName, certSuitePodNamespacedName,
+		err != nil || phase != definitions.CnfCertificationSuiteRunStatusPhaseJobFinished)
+
+	err = r.updateStatusPhase(runCrNamespacedName, phase)
 	if err != nil {
 		logger.Errorf("Failed to update status field Phase of CR %s: %v", runCrNamespacedName, err)
 	}
+
+	r.cleanUpRunResources(context.TODO(), runCrNamespacedName, certSuitePodNamespacedName.Namespace)
+	if deleteCompletedJobPods {
+		r.deleteJobPod(context.TODO(), certSuitePodNamespacedName)
+	}
+
+	// Sent once the run's resources are removed, as the retries of unreachable targets may last long.
+	notifyCtx, cancel := context.WithTimeout(context.Background(), notificationsTimeout)
+	defer cancel()
+	r.notifyRunCompletion(notifyCtx, runCrNamespacedName)
+}
+
+// Removes the job pod of a finished run. Its results and logs are already stored in the run CR's
+// status and artifacts config map.
+func (r *CnfCertificationSuiteRunReconciler) deleteJobPod(ctx context.Context, certSuitePodNamespacedName types.NamespacedName) {
+	certSuitePod := corev1.Pod{}
+	certSuitePod.Name = certSuitePodNamespacedName.Name
+	certSuitePod.Namespace = certSuitePodNamespacedName.Namespace
+	err := r.Delete(ctx, &certSuitePod)
+	if client.IgnoreNotFound(err) != nil {
+		logger.Errorf("Failed to remove CNF Cert job pod %s: %v", certSuitePodNamespacedName, err)
+		return
+	}
+	logger.Infof("CNF Cert job pod %s removed.", certSuitePodNamespacedName)
+}
+
+// Removes the resources that were created only for the lifetime of the run: the copies of the run
+// CR's config map and secret, and the generated RBAC resources.
+func (r *CnfCertificationSuiteRunReconciler) cleanUpRunResources(ctx context.Context, runCrNamespacedName types.NamespacedName, jobNamespace string) {
+	err := r.deleteRunResourcesCopies(ctx, runCrNamespacedName, jobNamespace)
+	if err != nil {
+		logger.Errorf("Failed to remove copied resources of CR %s: %v", runCrNamespacedName, err)
+	}
+
+	err = r.deleteRunRBAC(ctx, runCrNamespacedName, jobNamespace)
+	if err != nil {
+		logger.Errorf("Failed to remove generated RBAC resources of CR %s: %v", runCrNamespacedName, err)
+	}
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -215,57 +345,110 @@ func (r *CnfCertificationSuiteRunReconciler) Reconcile(ctx context.Context, req
 	var runCR cnfcertificationsv1alpha1.CnfCertificationSuiteRun
 	if getErr := r.Get(ctx, req.NamespacedName, &runCR); getErr != nil {
 		logger.Infof("CnfCertificationSuiteRun CR %s (ns %s) not found.", req.Name, req.NamespacedName)
-		if podName, exist := certificationRuns[runCrNamespacedName]; exist {
-			logger.Infof("CnfCertificationSuiteRun has been deleted. Removing the associated CNF Cert job pod %v", podName)
-			deleteErr := r.Delete(context.TODO(), &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: req.Namespace}})
-			if deleteErr != nil {
-				logger.Errorf("Failed to remove CNF Cert Job pod %s in namespace %s: %w", req.Name, req.Namespace, deleteErr)
-			}
-			delete(certificationRuns, runCrNamespacedName)
-		}
 		return ctrl.Result{}, client.IgnoreNotFound(getErr)
 	}
 
-	if podName, exist := certificationRuns[runCrNamespacedName]; exist {
-		logger.Infof("There's a certification job pod=%v running already. Ignoring changes in CnfCertificationSuiteRun %v", podName, runCrNamespacedName)
+	if !runCR.DeletionTimestamp.IsZero() {
+		err := r.finalizeRun(ctx, &runCR)
+		if err != nil {
+			logger.Errorf("Failed to finalize CnfCertificationSuiteRun %s: %v", runCrNamespacedName, err)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if runCR.Status.Phase != "" && runCR.Status.Phase != cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued {
+		logger.Infof("CnfCertificationSuiteRun %v has already been handled (phase %s). Ignoring it.", runCrNamespacedName, runCR.Status.Phase)
 		return ctrl.Result{}, nil
 	}
 
+	err := r.addCleanupFinalizer(ctx, &runCR)
+	if err != nil {
+		logger.Errorf("Failed to set up CnfCertificationSuiteRun %s: %v", runCrNamespacedName, err)
+		return ctrl.Result{}, err
+	}
+
+	queued, err := r.isRunQueued(ctx, &runCR)
+	if err != nil {
+		logger.Errorf("Failed to check the queue for CnfCertificationSuiteRun %s: %v", runCrNamespacedName, err)
+		return ctrl.Result{}, err
+	}
+	if queued {
+		return ctrl.Result{RequeueAfter: queuedRunCheckInterval}, nil
+	}
+
 	logger.Infof("New CNF Certification Job run requested: %v", runCrNamespacedName)
 
 	certSuitePodID++
 	certSuitePodName := fmt.Sprintf("%s-%d", definitions.CnfCertPodNamePrefix, certSuitePodID)
-
-	// Store the new run & associated CNF Cert pod name
-	certificationRuns[runCrNamespacedName] = certSuitePodName
+	certSuitePodNamespacedName := types.NamespacedName{Name: certSuitePodName, Namespace: getJobNamespace(&runCR)}
 
 	logger.Infof("Running CNF Certification Suite container (job id=%d) with labels %q, log level %q and timeout: %q",
 		certSuitePodID, runCR.Spec.LabelsFilter, runCR.Spec.LogLevel, runCR.Spec.TimeOut)
 
 	// Launch the pod with the CNF Cert Suite container plus the sidecar container to fetch the results.
-	err := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying)
+	err = r.updateStatus(runCrNamespacedName, func(status *cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus) {
+		status.Phase = cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying
+		status.QueuePosition = 0
+	})
 	if err != nil {
 		logger.Errorf("Failed to set status field Phase %s to CR %s: %v",
 			cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying, runCrNamespacedName, err)
 		return ctrl.Result{}, nil
 	}
 
+	serviceAccountName, err := r.setUpJobServiceAccount(ctx, &runCR, certSuitePodNamespacedName.Namespace)
+	if err != nil {
+		logger.Errorf("Failed to set up CNF Cert job pod's service account: %v", err)
+		r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonDeployFailed, "Failed to set up the CNF Cert job pod's service account: %v", err)
+		if updateErr := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError); updateErr != nil {
+			logger.Errorf("Failed to set status field Phase %s to CR %s: %v", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError, runCrNamespacedName, updateErr)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	configMapName, preflightSecretName, preflightSecretKey, err := r.setUpJobConfigResources(ctx, &runCR, certSuitePodNamespacedName.Namespace)
+	if err != nil {
+		logger.Errorf("Failed to set up CNF Cert job pod's config map and preflight secret: %v", err)
+		r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonValidationFailed, "Invalid config map or preflight secret: %v", err)
+		if updateErr := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError); updateErr != nil {
+			logger.Errorf("Failed to set status field Phase %s to CR %s: %v", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError, runCrNamespacedName, updateErr)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	offlineDB, err := r.setUpJobOfflineDB(ctx, &runCR, certSuitePodNamespacedName.Namespace)
+	if err != nil {
+		logger.Errorf("Failed to set up CNF Cert job pod's offline db: %v", err)
+		r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonValidationFailed, "Invalid offline db: %v", err)
+		if updateErr := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError); updateErr != nil {
+			logger.Errorf("Failed to set status field Phase %s to CR %s: %v", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError, runCrNamespacedName, updateErr)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	logger.Info("Creating CNF Cert job pod")
 	cnfCertJobPod, err := cnfcertjob.New(
 		cnfcertjob.WithPodName(certSuitePodName),
-		cnfcertjob.WithNamespace(req.Namespace),
+		cnfcertjob.WithNamespace(certSuitePodNamespacedName.Namespace),
+		cnfcertjob.WithRunLabels(runCR.Name, runCR.Namespace),
+		cnfcertjob.WithServiceAccountName(serviceAccountName),
 		cnfcertjob.WithCertSuiteConfigRunName(runCR.Name),
+		cnfcertjob.WithCertSuiteConfigRunNamespace(runCR.Namespace),
 		cnfcertjob.WithLabelsFilter(runCR.Spec.LabelsFilter),
 		cnfcertjob.WithLogLevel(runCR.Spec.LogLevel),
 		cnfcertjob.WithTimeOut(runCR.Spec.TimeOut),
-		cnfcertjob.WithConfigMap(runCR.Spec.ConfigMapName),
-		cnfcertjob.WithPreflightSecret(runCR.Spec.PreflightSecretName),
+		cnfcertjob.WithConfigMap(configMapName),
+		cnfcertjob.WithPreflightSecret(preflightSecretName, preflightSecretKey),
+		cnfcertjob.WithCertSuiteImage(runCR.Spec.CertSuiteImage),
 		cnfcertjob.WithSideCarApp(sideCarImage),
 		cnfcertjob.WithEnableDataCollection(strconv.FormatBool(runCR.Spec.EnableDataCollection)),
-		cnfcertjob.WithOwnerReference(runCR.UID, runCR.Name, runCR.Kind, runCR.APIVersion),
+		cnfcertjob.WithOfflineDB(offlineDB),
+		cnfcertjob.WithOwnerReference(runCR.UID, runCR.Name, runCR.Namespace, runCR.Kind, runCR.APIVersion),
 	)
 	if err != nil {
 		logger.Errorf("Failed to create CNF Cert job pod spec: %w", err)
+		r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonDeployFailed, "Failed to create the CNF Cert job pod spec: %v", err)
 		if updateErr := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError); updateErr != nil {
 			logger.Errorf("Failed to set status field Phase %s to CR %s: %v", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying, runCrNamespacedName, updateErr)
 		}
@@ -275,6 +458,7 @@ func (r *CnfCertificationSuiteRunReconciler) Reconcile(ctx context.Context, req
 	err = r.Create(ctx, cnfCertJobPod)
 	if err != nil {
 		logger.Errorf("Failed to create CNF Cert job pod: %w", err)
+		r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonDeployFailed, "Failed to create the CNF Cert job pod: %v", err)
 		if updateErr := r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError); updateErr != nil {
 			logger.Errorf("Failed to set status field Phase %s to CR %s: %v", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError, runCrNamespacedName, updateErr)
 		}
@@ -292,8 +476,10 @@ func (r *CnfCertificationSuiteRunReconciler) Reconcile(ctx context.Context, req
 	}
 
 	logger.Infof("Running CNF Cert job pod %s, triggered by CR %v", certSuitePodName, runCrNamespacedName)
+	r.Recorder.Eventf(&runCR, corev1.EventTypeNormal, eventReasonPodCreated, "Created CNF Cert job pod %s in namespace %s",
+		certSuitePodName, certSuitePodNamespacedName.Namespace)
 
-	go r.handleEndOfCnfCertSuiteRun(runCR.Name, certSuitePodName, runCR.Namespace, runCR.Spec.TimeOut)
+	go r.handleEndOfCnfCertSuiteRun(runCrNamespacedName, certSuitePodNamespacedName, runCR.Spec.TimeOut)
 	return ctrl.Result{}, nil
 }
 
@@ -364,7 +550,54 @@ func (r *CnfCertificationSuiteRunReconciler) SetupWithManager(mgr ctrl.Manager)
 		return fmt.Errorf("sidecar app img env var %q not found", definitions.SideCarImageEnvVar)
 	}
 
-	err := r.CreatePluginResources()
+	executionNamespace = os.Getenv(definitions.ExecutionNamespaceEnvVar)
+	if executionNamespace != "" {
+		logger.Infof("CNF Cert job pods will be created in namespace %s", executionNamespace)
+	}
+
+	generateRunRbac = os.Getenv(definitions.GenerateRunRbacEnvVar) == "true"
+	if generateRunRbac {
+		logger.Info("A service account with a scoped RBAC will be generated for every run.")
+	}
+
+	maxConcurrentRunsStr := os.Getenv(definitions.MaxConcurrentRunsEnvVar)
+	if maxConcurrentRunsStr != "" {
+		var err error
+		maxConcurrentRuns, err = strconv.Atoi(maxConcurrentRunsStr)
+		if err != nil || maxConcurrentRuns < 0 {
+			return fmt.Errorf("invalid max concurrent runs in env var %q: %q", definitions.MaxConcurrentRunsEnvVar, maxConcurrentRunsStr)
+		}
+		logger.Infof("Up to %d runs will be active at the same time.", maxConcurrentRuns)
+	}
+
+	deleteCompletedJobPods = os.Getenv(definitions.DeleteCompletedJobPodsEnvVar) == "true"
+	if deleteCompletedJobPods {
+		logger.Info("CNF Cert job pods will be removed once their run has finished.")
+	}
+
+	policy, err := getRetentionPolicyFromEnv()
+	if err != nil {
+		return err
+	}
+	if policy.isEnabled() {
+		logger.Infof("Finished runs will be removed after exceeding the retention policy: %+v", *policy)
+		err = mgr.Add(&runGarbageCollector{reconciler: r, policy: *policy})
+		if err != nil {
+			return fmt.Errorf("failed to add the runs garbage collector: %w", err)
+		}
+	}
+
+	err = mgr.Add(&runRecoverer{reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to add the runs recoverer: %w", err)
+	}
+
+	err = metrics.Register(mgr.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to register the runs metrics: %w", err)
+	}
+
+	err = r.CreatePluginResources()
 	if err != nil {
 		return fmt.Errorf("failed to create plugin, err: %v", err)
 	}
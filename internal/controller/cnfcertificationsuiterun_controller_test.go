@@ -3,6 +3,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,22 +12,27 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 )
 
 // Creates a reconciler with a fake client to mock API calls.
 func mockReconciler(objs []runtime.Object) *CnfCertificationSuiteRunReconciler {
 	s := scheme.Scheme
-	s.AddKnownTypes(cnfcertificationsv1alpha1.GroupVersion, &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{})
+	s.AddKnownTypes(cnfcertificationsv1alpha1.GroupVersion, &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}, &cnfcertificationsv1alpha1.CnfCertificationSuiteRunList{},
+		&cnfcertificationsv1alpha1.CnfCertificationNotifier{}, &cnfcertificationsv1alpha1.CnfCertificationNotifierList{})
 
 	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
 	cl := fake.NewClientBuilder().WithRuntimeObjects(objs...).WithStatusSubresource(runCR).Build()
 
-	return &CnfCertificationSuiteRunReconciler{Client: cl, Scheme: s}
+	return &CnfCertificationSuiteRunReconciler{Client: cl, Scheme: s, Recorder: record.NewFakeRecorder(100), KubeClient: k8sfake.NewSimpleClientset()}
 }
 
 func Test_getJobRunTimeThreshold(t *testing.T) {
@@ -108,6 +115,23 @@ func Test_getCertSuiteContainerExitStatus(t *testing.T) {
 			wantExitStatus: 0,
 			wantError:      fmt.Errorf("failed to get cert suite exit status: container not found in pod cnf-job-sample (ns cnf-certsuite-operator)"),
 		},
+		{ // Test case #4 - Fail with "container didn't run" error
+			name: "Container cnf-certsuite didn't run",
+			certSuitePod: &corev1.Pod{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "cnf-job-sample",
+					Namespace: "cnf-certsuite-operator",
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  definitions.CnfCertSuiteContainerName,
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+					}},
+				},
+			},
+			wantExitStatus: 0,
+			wantError:      fmt.Errorf("failed to get cert suite exit status: container didn't run in pod cnf-job-sample (ns cnf-certsuite-operator)"),
+		},
 	}
 	for _, tc := range tests {
 		gotExitStatus, gotErr := getCertSuiteContainerExitStatus(tc.certSuitePod)
@@ -242,3 +266,39 @@ func TestCnfCertificationSuiteRunReconciler_updateStatus(t *testing.T) {
 		}
 	}
 }
+
+// Returns the phases allowed by the run CRD's status schema.
+func getCrdStatusPhases(t *testing.T) []string {
+	crdFile, err := os.ReadFile("../../config/crd/bases/cnf-certifications.redhat.com_cnfcertificationsuiteruns.yaml")
+	if err != nil {
+		t.Fatalf("failed to read the run CRD: %v", err)
+	}
+
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(crdFile, &crd); err != nil {
+		t.Fatalf("failed to parse the run CRD: %v", err)
+	}
+
+	phases := []string{}
+	for _, version := range crd.Spec.Versions {
+		phaseSchema := version.Schema.OpenAPIV3Schema.Properties["status"].Properties["phase"]
+		for _, phase := range phaseSchema.Enum {
+			phases = append(phases, strings.Trim(string(phase.Raw), `"`))
+		}
+	}
+	return phases
+}
+
+func TestCrdStatusPhases(t *testing.T) {
+	phases := getCrdStatusPhases(t)
+	for _, phase := range []string{
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+	} {
+		assert.Contains(t, phases, phase)
+	}
+}
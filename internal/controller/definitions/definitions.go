@@ -1,22 +1,26 @@
 package definitions
 
 const (
-	CnfCertificationSuiteRunStatusPhaseCertSuiteDeploying     = "CertSuiteDeploying"
-	CnfCertificationSuiteRunStatusPhaseCertSuiteDeployFailure = "CertSuiteDeployFailure"
-	CnfCertificationSuiteRunStatusCertSuiteRunning            = "CertSuiteRunning"
-	CnfCertificationSuiteRunStatusPhaseJobFinished            = "CertSuiteFinished"
-	CnfCertificationSuiteRunStatusPhaseJobError               = "CertSuiteError"
+	CnfCertificationSuiteRunStatusPhaseCertSuiteDeploying   = "CertSuiteDeploying"
+	CnfCertificationSuiteRunStatusPhaseCertSuiteDeployError = "CertSuiteDeployError"
+	CnfCertificationSuiteRunStatusCertSuiteRunning          = "CertSuiteRunning"
+	CnfCertificationSuiteRunStatusPhaseJobFinished          = "CertSuiteFinished"
+	CnfCertificationSuiteRunStatusPhaseJobError             = "CertSuiteError"
 )
 
 const (
 	CnfCertPodNamePrefix             = "cnf-job-run"
 	CnfCertSuiteSidecarContainerName = "cnf-certsuite-sidecar"
 	CnfCertSuiteContainerName        = "cnf-certsuite"
+	OfflineDBInitContainerName       = "offline-db"
 
 	CnfCertSuiteBaseFolder      = "/cnf-certsuite"
 	CnfCnfCertSuiteConfigFolder = CnfCertSuiteBaseFolder + "/config/suite"
 	CnfPreflightConfigFolder    = CnfCertSuiteBaseFolder + "/config/preflight"
 	CnfCertSuiteResultsFolder   = CnfCertSuiteBaseFolder + "/results"
+	CnfCertSuiteOfflineDBFolder = CnfCertSuiteBaseFolder + "/offline-db"
+	// Folder of the offline certification database in the images, if not set in the run CR.
+	DefaultOfflineDBImagePath = "/offline-db"
 
 	CnfCertSuiteConfigFilePath    = CnfCnfCertSuiteConfigFolder + "/tnf_config.yaml"
 	PreflightDockerConfigFilePath = CnfPreflightConfigFolder + "/preflight_dockerconfig.json"
@@ -24,4 +28,68 @@ const (
 	SideCarResultsFolderEnvVar = "TNF_RESULTS_FOLDER"
 	SideCarImageEnvVar         = "SIDECAR_APP_IMG"
 	ControllerNamespaceEnvVar  = "CONTROLLER_NS"
+	ExecutionNamespaceEnvVar   = "EXECUTION_NAMESPACE"
+	GenerateRunRbacEnvVar      = "GENERATE_RUN_RBAC"
+	DefaultRunTimeoutEnvVar    = "DEFAULT_RUN_TIMEOUT"
+	MinRunTimeoutEnvVar        = "MIN_RUN_TIMEOUT"
+	MaxRunTimeoutEnvVar        = "MAX_RUN_TIMEOUT"
+	RunDefaultsConfigMapEnvVar = "RUN_DEFAULTS_CONFIGMAP"
+	MaxConcurrentRunsEnvVar    = "MAX_CONCURRENT_RUNS"
+
+	RunRetentionMaxRunsEnvVar           = "RUN_RETENTION_MAX_RUNS"
+	RunRetentionMaxAgeEnvVar            = "RUN_RETENTION_MAX_AGE"
+	RunRetentionKeepLastPerTargetEnvVar = "RUN_RETENTION_KEEP_LAST_PER_TARGET"
+	RunRetentionFailedMaxAgeEnvVar      = "RUN_RETENTION_FAILED_MAX_AGE"
+	DeleteCompletedJobPodsEnvVar        = "DELETE_COMPLETED_JOB_PODS"
 )
+
+const (
+	// Be careful when changing this SA name.
+	// 1. It must match the flag --extra-service-accounts in "make bundle".
+	// 2. The prefix is "cnf-certsuite-". It should match the field namePrefix field in config/default/kustomization.yaml.
+	JobServiceAccountName = "cnf-certsuite-cluster-access"
+)
+
+// Cluster roles bound to the service accounts generated for the runs, installed along with the
+// operator. The controller is only allowed to bind these ones, so their names must match the ones
+// in config/rbac/run_rbac_roles.yaml, including the "cnf-certsuite-" namePrefix.
+const (
+	// Read-only access to the cluster, except secrets.
+	RunClusterReaderRoleName = "cnf-certsuite-run-cluster-reader"
+	// Access needed by the CNF Cert Suite in the target namespaces.
+	RunNamespaceTesterRoleName = "cnf-certsuite-run-namespace-tester"
+	// Access needed by the CNF Cert Suite to deploy its probe daemonset in its namespace.
+	RunProbeNamespaceRoleName = "cnf-certsuite-run-probe-namespace"
+	// Access needed by the sidecar to publish the results in the run CR's namespace.
+	RunSideCarRoleName = "cnf-certsuite-run-sidecar"
+)
+
+// Keys of the run defaults config map, which holds the values set to the run CRs' fields that
+// are not set by the user.
+const (
+	RunDefaultsLogLevelKey            = "logLevel"
+	RunDefaultsTimeoutKey             = "timeout"
+	RunDefaultsLabelsFilterKey        = "labelsFilter"
+	RunDefaultsPreflightSecretNameKey = "preflightSecretName"
+	RunDefaultsCertSuiteImageKey      = "certSuiteImage"
+)
+
+const (
+	// Finalizer set to every CnfCertificationSuiteRun, so its resources are removed before the CR is deleted.
+	RunCleanupFinalizer = "cnf-certifications.redhat.com/cleanup"
+	// Annotation that, set to "true", prevents a CnfCertificationSuiteRun from being deleted.
+	RunProtectAnnotation = "cnf-certifications.redhat.com/protect"
+	// Annotation that, set to "true", prevents a CnfCertificationSuiteRun from being removed by the
+	// controller's retention policy.
+	RunPinAnnotation = "cnf-certifications.redhat.com/pin"
+)
+
+// Labels set in every resource created by the controller for a CnfCertificationSuiteRun.
+const (
+	RunCrNameLabel      = "cnf-certifications.redhat.com/run-name"
+	RunCrNamespaceLabel = "cnf-certifications.redhat.com/run-namespace"
+)
+
+// Annotation set in every resource generated for a CnfCertificationSuiteRun, holding the run CR's
+// "namespace/name", as the resources' names are shortened.
+const RunCrAnnotation = "cnf-certifications.redhat.com/run"
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+// Reasons of the events recorded in the CnfCertificationSuiteRuns by the controller.
+const (
+	eventReasonQueued               = "Queued"
+	eventReasonValidationFailed     = "ValidationFailed"
+	eventReasonDeployFailed         = "DeployFailed"
+	eventReasonPodCreated           = "PodCreated"
+	eventReasonPodUnschedulable     = "PodUnschedulable"
+	eventReasonSuiteFinished        = "SuiteFinished"
+	eventReasonSuiteFailed          = "SuiteFailed"
+	eventReasonTimeout              = "Timeout"
+	eventReasonSidecarPublishFailed = "SidecarPublishFailed"
+	eventReasonOfflineDBFailed      = "OfflineDBFailed"
+	eventReasonPodLost              = "PodLost"
+)
+
+// Records an event in the run CR. The CR is read first, as the events must refer to the CR's
+// current uid. Failures are only logged, as events are informative.
+func (r *CnfCertificationSuiteRunReconciler) recordRunEvent(runCrNamespacedName types.NamespacedName, eventType, reason, messageFmt string, args ...interface{}) {
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	err := r.Get(context.TODO(), runCrNamespacedName, &runCR)
+	if err != nil {
+		logger.Errorf("Failed to record event %s in CR %s: %v", reason, runCrNamespacedName, err)
+		return
+	}
+
+	r.Recorder.Eventf(&runCR, eventType, reason, messageFmt, args...)
+}
+
+// Returns the scheduler's message if the pod can't be scheduled.
+func getPodUnschedulableMessage(pod *corev1.Pod) (string, bool) {
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return condition.Message, true
+		}
+	}
+
+	return "", false
+}
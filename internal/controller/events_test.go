@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"testing"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_getPodUnschedulableMessage(t *testing.T) {
+	tests := []struct {
+		name              string
+		conditions        []corev1.PodCondition
+		wantMessage       string
+		wantUnschedulable bool
+	}{
+		{ // Test case #1 - Pod not scheduled yet
+			name:       "Pod without conditions",
+			conditions: nil,
+		},
+		{ // Test case #2 - Pod scheduled
+			name:       "Pod scheduled",
+			conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		},
+		{ // Test case #3 - Scheduler couldn't find a node
+			name: "Pod unschedulable",
+			conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient memory.",
+			}},
+			wantMessage:       "0/3 nodes are available: 3 Insufficient memory.",
+			wantUnschedulable: true,
+		},
+	}
+
+	for _, tc := range tests {
+		pod := &corev1.Pod{Status: corev1.PodStatus{Conditions: tc.conditions}}
+		gotMessage, gotUnschedulable := getPodUnschedulableMessage(pod)
+		assert.Equal(t, tc.wantMessage, gotMessage, tc.name)
+		assert.Equal(t, tc.wantUnschedulable, gotUnschedulable, tc.name)
+	}
+}
+
+func TestCnfCertificationSuiteRunReconciler_recordRunEvent(t *testing.T) {
+	runCrNamespacedName := types.NamespacedName{Name: "cnf-run", Namespace: "cnf-ns"}
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: runCrNamespacedName.Name, Namespace: runCrNamespacedName.Namespace},
+	}
+
+	r := mockReconciler([]runtime.Object{runCR})
+	recorder := r.Recorder.(*record.FakeRecorder)
+
+	r.recordRunEvent(runCrNamespacedName, corev1.EventTypeWarning, eventReasonTimeout, "Pod %s didn't finish", "cnf-job-run-1")
+	assert.Equal(t, "Warning Timeout Pod cnf-job-run-1 didn't finish", <-recorder.Events)
+
+	// No event is recorded if the CR doesn't exist anymore.
+	r.recordRunEvent(types.NamespacedName{Name: "deleted-run", Namespace: "cnf-ns"}, corev1.EventTypeNormal, eventReasonSuiteFinished, "Finished")
+	assert.Empty(t, recorder.Events)
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// Adds the cleanup finalizer to the run CR, in case it doesn't have it yet.
+func (r *CnfCertificationSuiteRunReconciler) addCleanupFinalizer(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) error {
+	if !controllerutil.AddFinalizer(runCR, definitions.RunCleanupFinalizer) {
+		return nil
+	}
+
+	err := r.Update(ctx, runCR)
+	if err != nil {
+		return fmt.Errorf("failed to add finalizer %s to CR %s (ns %s): %w", definitions.RunCleanupFinalizer, runCR.Name, runCR.Namespace, err)
+	}
+	return nil
+}
+
+// Removes every resource created for a run CR that is being deleted, and then its cleanup
+// finalizer so the deletion can go on.
+func (r *CnfCertificationSuiteRunReconciler) finalizeRun(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) error {
+	if !controllerutil.ContainsFinalizer(runCR, definitions.RunCleanupFinalizer) {
+		return nil
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	jobNamespace := getJobNamespace(runCR)
+	logger.Infof("CnfCertificationSuiteRun %s is being deleted. Removing its resources.", runCrNamespacedName)
+
+	namespaces := []string{runCR.Namespace}
+	if jobNamespace != runCR.Namespace {
+		namespaces = append(namespaces, jobNamespace)
+	}
+
+	err := r.deleteRunResources(ctx, runCrNamespacedName, namespaces)
+	if err != nil {
+		return err
+	}
+
+	err = r.deleteRunRBAC(ctx, runCrNamespacedName, jobNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to remove generated RBAC resources of CR %s: %w", runCrNamespacedName, err)
+	}
+
+	controllerutil.RemoveFinalizer(runCR, definitions.RunCleanupFinalizer)
+	err = r.Update(ctx, runCR)
+	if err != nil {
+		return fmt.Errorf("failed to remove finalizer %s from CR %s: %w", definitions.RunCleanupFinalizer, runCrNamespacedName, err)
+	}
+
+	return nil
+}
+
+// Removes the job pod, config maps and secrets created for a run CR in the given namespaces.
+func (r *CnfCertificationSuiteRunReconciler) deleteRunResources(ctx context.Context, runCrNamespacedName types.NamespacedName, namespaces []string) error {
+	runLabels := client.MatchingLabels(getRunLabels(runCrNamespacedName))
+	for _, namespace := range namespaces {
+		for _, obj := range []client.Object{&corev1.Pod{}, &corev1.ConfigMap{}, &corev1.Secret{}} {
+			err := r.DeleteAllOf(ctx, obj, client.InNamespace(namespace), runLabels)
+			if err != nil {
+				return fmt.Errorf("failed to delete %T resources of run %s in namespace %s: %w", obj, runCrNamespacedName, namespace, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCnfCertificationSuiteRunReconciler_finalizeRun(t *testing.T) {
+	runCrNamespacedName := types.NamespacedName{Name: "cnf-run", Namespace: "cnf-ns"}
+	runLabels := getRunLabels(runCrNamespacedName)
+	now := v1.Now()
+
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              runCrNamespacedName.Name,
+			Namespace:         runCrNamespacedName.Namespace,
+			Finalizers:        []string{definitions.RunCleanupFinalizer},
+			DeletionTimestamp: &now,
+		},
+	}
+	jobPod := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "cnf-job-run-1", Namespace: "cnf-ns", Labels: runLabels}}
+	generatedConfigMap := &corev1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "cnf-ns-cnf-run-config", Namespace: "cnf-ns", Labels: runLabels}}
+	userConfigMap := &corev1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "cnf-certsuite-config", Namespace: "cnf-ns"}}
+
+	r := mockReconciler([]runtime.Object{runCR, jobPod, generatedConfigMap, userConfigMap})
+
+	err := r.finalizeRun(context.TODO(), runCR)
+	assert.Nil(t, err)
+
+	// The resources created for the run must have been removed, but not the user's ones.
+	err = r.Get(context.TODO(), types.NamespacedName{Name: jobPod.Name, Namespace: jobPod.Namespace}, &corev1.Pod{})
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Get(context.TODO(), types.NamespacedName{Name: generatedConfigMap.Name, Namespace: generatedConfigMap.Namespace}, &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Get(context.TODO(), types.NamespacedName{Name: userConfigMap.Name, Namespace: userConfigMap.Namespace}, &corev1.ConfigMap{})
+	assert.Nil(t, err)
+
+	// Once the finalizer is removed, the CR is deleted.
+	err = r.Get(context.TODO(), runCrNamespacedName, &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{})
+	assert.True(t, errors.IsNotFound(err))
+}
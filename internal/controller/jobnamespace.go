@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/dockerconfig"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// Returns the namespace where the CNF Cert job pod of a run CR is created: the configured
+// execution namespace, if any, or the run CR's namespace otherwise.
+func getJobNamespace(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) string {
+	if executionNamespace != "" {
+		return executionNamespace
+	}
+	return runCR.Namespace
+}
+
+// Returns the labels that identify the resources created for a run CR.
+func getRunLabels(runCrNamespacedName types.NamespacedName) map[string]string {
+	return map[string]string{
+		definitions.RunCrNameLabel:      runCrNamespacedName.Name,
+		definitions.RunCrNamespaceLabel: runCrNamespacedName.Namespace,
+	}
+}
+
+// Returns the annotations holding the run CR's namespace and name in the resources created for it.
+func getRunAnnotations(runCrNamespacedName types.NamespacedName) map[string]string {
+	return map[string]string{definitions.RunCrAnnotation: runCrNamespacedName.String()}
+}
+
+// Makes sure the job pods' service account exists in the given namespace. It's not created by
+// the controller, as it's bound to a cluster-wide role: it must be provisioned by the cluster
+// admin in the namespaces where the job pods are allowed to run.
+func (r *CnfCertificationSuiteRunReconciler) ensureJobServiceAccount(ctx context.Context, namespace string) error {
+	serviceAccount := corev1.ServiceAccount{}
+	err := r.Get(ctx, types.NamespacedName{Name: definitions.JobServiceAccountName, Namespace: namespace}, &serviceAccount)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("service account %s not found in namespace %s: it must be created by the cluster admin, "+
+			"or the run CR must set its own service account", definitions.JobServiceAccountName, namespace)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get service account %s (ns %s): %w", definitions.JobServiceAccountName, namespace, err)
+	}
+
+	return nil
+}
+
+// Sets up the config map and preflight secret to be mounted in the job pod of a run CR, which
+// must be in the job's namespace. Returns their names, and the key of the preflight secret with
+// the docker config json.
+func (r *CnfCertificationSuiteRunReconciler) setUpJobConfigResources(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (configMapName string, preflightSecretName *string, preflightSecretKey string, err error) {
+	configMapName, err = r.setUpJobConfigMap(ctx, runCR, jobNamespace)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	preflightSecretName, preflightSecretKey, err = r.setUpJobPreflightSecret(ctx, runCR, jobNamespace)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	return configMapName, preflightSecretName, preflightSecretKey, nil
+}
+
+// Returns the name of the config map with the CNF Cert Suite config for the job pod:
+//   - If the run CR has the config in its spec, a config map is generated with its content.
+//   - If the job's namespace is not the run CR's one, its config map is copied there.
+//   - Otherwise, the run CR's config map is used.
+func (r *CnfCertificationSuiteRunReconciler) setUpJobConfigMap(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (string, error) {
+	if runCR.Spec.Config == nil && jobNamespace == runCR.Namespace {
+		return runCR.Spec.ConfigMapName, nil
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	jobConfigMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getRunResourcesName(runCrNamespacedName) + "-config",
+			Namespace:   jobNamespace,
+			Labels:      getRunLabels(runCrNamespacedName),
+			Annotations: getRunAnnotations(runCrNamespacedName),
+		},
+	}
+
+	if runCR.Spec.Config != nil {
+		data, err := renderCertSuiteConfig(runCR.Spec.Config)
+		if err != nil {
+			return "", err
+		}
+		jobConfigMap.Data = data
+
+		// Generated config maps in the run CR's namespace are removed along with it.
+		if jobNamespace == runCR.Namespace {
+			err = controllerutil.SetOwnerReference(runCR, &jobConfigMap, r.Scheme)
+			if err != nil {
+				return "", fmt.Errorf("failed to set owner reference to config map %s: %w", jobConfigMap.Name, err)
+			}
+		}
+	} else {
+		configMap := corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Name: runCR.Spec.ConfigMapName, Namespace: runCR.Namespace}, &configMap)
+		if err != nil {
+			return "", fmt.Errorf("failed to get config map %s (ns %s): %w", runCR.Spec.ConfigMapName, runCR.Namespace, err)
+		}
+		jobConfigMap.Data = configMap.Data
+	}
+
+	err := r.createOrUpdateRunResource(ctx, &jobConfigMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to create config map %s in namespace %s: %w", jobConfigMap.Name, jobNamespace, err)
+	}
+
+	return jobConfigMap.Name, nil
+}
+
+// Returns the name of the preflight secret for the job pod, and the key with its docker config
+// json. In case the job's namespace is not the run CR's one, its secret is copied there.
+func (r *CnfCertificationSuiteRunReconciler) setUpJobPreflightSecret(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (*string, string, error) {
+	if runCR.Spec.PreflightSecretName == nil {
+		return nil, "", nil
+	}
+
+	secret := corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: *runCR.Spec.PreflightSecretName, Namespace: runCR.Namespace}, &secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get preflight secret %s (ns %s): %w", *runCR.Spec.PreflightSecretName, runCR.Namespace, err)
+	}
+
+	secretKey, found := dockerconfig.GetSecretKey(&secret)
+	if !found {
+		return nil, "", fmt.Errorf("preflight secret %s (ns %s) has no docker config json", secret.Name, secret.Namespace)
+	}
+
+	if jobNamespace == runCR.Namespace {
+		return runCR.Spec.PreflightSecretName, secretKey, nil
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	secretCopy := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getRunResourcesName(runCrNamespacedName) + "-preflight",
+			Namespace:   jobNamespace,
+			Labels:      getRunLabels(runCrNamespacedName),
+			Annotations: getRunAnnotations(runCrNamespacedName),
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	err = r.createOrUpdateRunResource(ctx, &secretCopy)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to copy preflight secret %s to namespace %s: %w", secret.Name, jobNamespace, err)
+	}
+
+	return &secretCopy.Name, secretKey, nil
+}
+
+// Creates a resource generated for a run CR. In case it already exists, e.g. when a failed
+// deployment of the run is retried, it's updated instead. The existing one is read from the API
+// server, as the job's namespace may not be watched by the controller.
+func (r *CnfCertificationSuiteRunReconciler) createOrUpdateRunResource(ctx context.Context, obj client.Object) error {
+	err := r.Create(ctx, obj)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected type %T of resource %s", obj, obj.GetName())
+	}
+	err = r.APIReader.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return r.Update(ctx, obj)
+}
+
+// Removes the config maps and secrets that were created in the job's namespace for a run CR, in
+// case it's not the run CR's one.
+func (r *CnfCertificationSuiteRunReconciler) deleteRunResourcesCopies(ctx context.Context, runCrNamespacedName types.NamespacedName, jobNamespace string) error {
+	if jobNamespace == runCrNamespacedName.Namespace {
+		return nil
+	}
+
+	runLabels := client.MatchingLabels(getRunLabels(runCrNamespacedName))
+	err := r.DeleteAllOf(ctx, &corev1.ConfigMap{}, client.InNamespace(jobNamespace), runLabels)
+	if err != nil {
+		return fmt.Errorf("failed to delete config maps of run %s in namespace %s: %w", runCrNamespacedName, jobNamespace, err)
+	}
+
+	err = r.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(jobNamespace), runLabels)
+	if err != nil {
+		return fmt.Errorf("failed to delete secrets of run %s in namespace %s: %w", runCrNamespacedName, jobNamespace, err)
+	}
+
+	return nil
+}
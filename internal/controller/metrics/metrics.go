@@ -0,0 +1,159 @@
+// Package metrics holds the Prometheus metrics about the CNF Certification Suite runs, which are
+// served by the manager's metrics endpoint.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+const (
+	namespace = "certsuite"
+
+	// Max time to list the runs when the metrics are scraped.
+	collectTimeout = 10 * time.Second
+)
+
+var (
+	// RunDuration observes the duration of the finished runs, from the job pod creation until it
+	// completes, by final phase.
+	RunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "run_duration_seconds",
+		Help:      "Duration of the CNF Certification Suite runs, by final phase.",
+		// From 1 minute to ~17 hours.
+		Buckets: prometheus.ExponentialBuckets(60, 2, 11),
+	}, []string{"phase"})
+
+	// SidecarPublishFailures counts the runs whose sidecar failed to publish the results in the
+	// run CR's status.
+	SidecarPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sidecar_publish_failures_total",
+		Help:      "Number of runs whose sidecar failed to publish the results, by run namespace.",
+	}, []string{"namespace"})
+)
+
+var (
+	runsDesc = prometheus.NewDesc(namespace+"_runs",
+		"Number of CnfCertificationSuiteRuns, by phase.",
+		[]string{"phase"}, nil)
+	runTestsDesc = prometheus.NewDesc(namespace+"_run_tests",
+		"Number of test cases of a run, by result.",
+		[]string{"namespace", "run", "result"}, nil)
+	testResultDesc = prometheus.NewDesc(namespace+"_test_result",
+		"Result of a test case in a run. The value is always 1.",
+		[]string{"namespace", "run", "test_id", "result"}, nil)
+	targetNamespaceVerdictDesc = prometheus.NewDesc(namespace+"_target_namespace_last_verdict",
+		"Verdict of the latest finished run testing a CNF namespace. The value is always 1.",
+		[]string{"target_namespace", "namespace", "run", "verdict"}, nil)
+)
+
+var phases = []string{
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued,
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying,
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError,
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning,
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+	cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+}
+
+// runsCollector builds the metrics about the runs' phases and results from the CRs, every time
+// the metrics are scraped, so they are always in sync with the CRs and the deleted runs go away.
+type runsCollector struct {
+	reader client.Reader
+}
+
+func (c *runsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- runsDesc
+	ch <- runTestsDesc
+	ch <- testResultDesc
+	ch <- targetNamespaceVerdictDesc
+}
+
+func (c *runsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	runs := cnfcertificationsv1alpha1.CnfCertificationSuiteRunList{}
+	err := c.reader.List(ctx, &runs)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(runsDesc, err)
+		return
+	}
+
+	collectRunsMetrics(runs.Items, ch)
+}
+
+func collectRunsMetrics(runs []cnfcertificationsv1alpha1.CnfCertificationSuiteRun, ch chan<- prometheus.Metric) {
+	runsByPhase := map[string]int{}
+	for _, phase := range phases {
+		runsByPhase[phase] = 0
+	}
+
+	// Latest run with a report for every target namespace.
+	latestRuns := map[string]*cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+
+	for i := range runs {
+		run := &runs[i]
+		if run.Status.Phase != "" {
+			runsByPhase[string(run.Status.Phase)]++
+		}
+
+		report := run.Status.Report
+		if report == nil {
+			continue
+		}
+
+		summary := report.Summary
+		for result, count := range map[string]int{
+			cnfcertificationsv1alpha1.StatusStatePassed:  summary.Passed,
+			cnfcertificationsv1alpha1.StatusStateSkipped: summary.Skipped,
+			cnfcertificationsv1alpha1.StatusStateFailed:  summary.Failed,
+			cnfcertificationsv1alpha1.StatusStateError:   summary.Errored,
+			cnfcertificationsv1alpha1.StatusStateWaived:  summary.Waived,
+		} {
+			ch <- prometheus.MustNewConstMetric(runTestsDesc, prometheus.GaugeValue, float64(count), run.Namespace, run.Name, result)
+		}
+
+		for j := range report.Results {
+			result := &report.Results[j]
+			ch <- prometheus.MustNewConstMetric(testResultDesc, prometheus.GaugeValue, 1, run.Namespace, run.Name, result.TestCaseName, result.Result)
+		}
+
+		for _, targetNamespace := range report.CnfTargets.Namespaces {
+			latest, found := latestRuns[targetNamespace]
+			if !found || latest.CreationTimestamp.Before(&run.CreationTimestamp) {
+				latestRuns[targetNamespace] = run
+			}
+		}
+	}
+
+	for phase, count := range runsByPhase {
+		ch <- prometheus.MustNewConstMetric(runsDesc, prometheus.GaugeValue, float64(count), phase)
+	}
+
+	for targetNamespace, run := range latestRuns {
+		ch <- prometheus.MustNewConstMetric(targetNamespaceVerdictDesc, prometheus.GaugeValue, 1,
+			targetNamespace, run.Namespace, run.Name, run.Status.Report.Verdict)
+	}
+}
+
+// Register registers the runs metrics in the controller-runtime's registry, so they're served
+// along with the controller-runtime metrics. The runs are listed with the given reader.
+func Register(reader client.Reader) error {
+	for _, collector := range []prometheus.Collector{RunDuration, SidecarPublishFailures, &runsCollector{reader: reader}} {
+		err := ctrlmetrics.Registry.Register(collector)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
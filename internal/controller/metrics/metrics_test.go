@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func newRun(name string, createdMinutesAgo int, phase cnfcertificationsv1alpha1.StatusPhase,
+	report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) *cnfcertificationsv1alpha1.CnfCertificationSuiteRun {
+	return &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "cnf-ns",
+			CreationTimestamp: v1.NewTime(time.Now().Add(-time.Duration(createdMinutesAgo) * time.Minute)),
+		},
+		Status: cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{Phase: phase, Report: report},
+	}
+}
+
+func TestRunsCollector(t *testing.T) {
+	oldReport := &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Verdict:    cnfcertificationsv1alpha1.StatusVerdictPass,
+		CnfTargets: cnfcertificationsv1alpha1.CnfTargets{Namespaces: []string{"tnf"}},
+		Summary:    cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary{Total: 1, Passed: 1},
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{TestCaseName: "observability-crd-status", Result: cnfcertificationsv1alpha1.StatusStatePassed},
+		},
+	}
+	newReport := &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Verdict:    cnfcertificationsv1alpha1.StatusVerdictFail,
+		CnfTargets: cnfcertificationsv1alpha1.CnfTargets{Namespaces: []string{"tnf"}},
+		Summary:    cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary{Total: 1, Failed: 1},
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{TestCaseName: "observability-crd-status", Result: cnfcertificationsv1alpha1.StatusStateFailed},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.Nil(t, cnfcertificationsv1alpha1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		newRun("run1", 20, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, oldReport),
+		newRun("run2", 10, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, newReport),
+		newRun("run3", 1, cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, nil),
+	).Build()
+
+	expected := `
+# HELP certsuite_runs Number of CnfCertificationSuiteRuns, by phase.
+# TYPE certsuite_runs gauge
+certsuite_runs{phase="CertSuiteDeployError"} 0
+certsuite_runs{phase="CertSuiteDeploying"} 0
+certsuite_runs{phase="CertSuiteError"} 0
+certsuite_runs{phase="CertSuiteFinished"} 2
+certsuite_runs{phase="CertSuiteQueued"} 0
+certsuite_runs{phase="CertSuiteRunning"} 1
+# HELP certsuite_target_namespace_last_verdict Verdict of the latest finished run testing a CNF namespace. The value is always 1.
+# TYPE certsuite_target_namespace_last_verdict gauge
+certsuite_target_namespace_last_verdict{namespace="cnf-ns",run="run2",target_namespace="tnf",verdict="fail"} 1
+# HELP certsuite_test_result Result of a test case in a run. The value is always 1.
+# TYPE certsuite_test_result gauge
+certsuite_test_result{namespace="cnf-ns",result="failed",run="run2",test_id="observability-crd-status"} 1
+certsuite_test_result{namespace="cnf-ns",result="passed",run="run1",test_id="observability-crd-status"} 1
+`
+
+	err := testutil.CollectAndCompare(&runsCollector{reader: reader}, strings.NewReader(expected),
+		"certsuite_runs", "certsuite_target_namespace_last_verdict", "certsuite_test_result")
+	assert.Nil(t, err)
+
+	// Five results (passed, skipped, failed, error and waived) per run with report.
+	assert.Equal(t, 10, testutil.CollectAndCount(&runsCollector{reader: reader}, "certsuite_run_tests"))
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/notification"
+)
+
+const (
+	defaultNotificationMaxAttempts = 3
+	eventReasonNotificationFailed  = "NotificationFailed"
+	// Max time to send the notifications of a run, including the retries.
+	notificationsTimeout = 5 * time.Minute
+)
+
+// Backoff before retrying a failed notification, doubled on every attempt.
+var notificationRetryBackoff = 5 * time.Second
+
+// +kubebuilder:rbac:groups=cnf-certifications.redhat.com,resources=cnfcertificationnotifiers,verbs=get;list;watch
+
+// Returns true if the notifier applies to the run.
+func notifierMatchesRun(notifier *cnfcertificationsv1alpha1.CnfCertificationNotifier, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, verdict string) (bool, error) {
+	if notifier.Spec.RunSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(notifier.Spec.RunSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid run selector: %w", err)
+		}
+		if !selector.Matches(labels.Set(runCR.Labels)) {
+			return false, nil
+		}
+	}
+
+	return len(notifier.Spec.Verdicts) == 0 || slices.Contains(notifier.Spec.Verdicts, cnfcertificationsv1alpha1.NotifierVerdict(verdict)), nil
+}
+
+// Returns the value of a key of a secret in the given namespace.
+func (r *CnfCertificationSuiteRunReconciler) getSecretValue(ctx context.Context, namespace, name, key string) (string, error) {
+	secret := corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	value, found := secret.Data[key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in secret %s", key, name)
+	}
+	return string(value), nil
+}
+
+// Returns the notifier's target with the values of its secrets.
+func (r *CnfCertificationSuiteRunReconciler) resolveNotifierTarget(ctx context.Context, namespace string, target *cnfcertificationsv1alpha1.NotifierTarget) (*notification.Target, error) {
+	resolved := notification.Target{Type: target.Type, URL: target.URL}
+
+	if target.URLSecretRef != nil {
+		url, err := r.getSecretValue(ctx, namespace, target.URLSecretRef.Name, target.URLSecretRef.Key)
+		if err != nil {
+			return nil, err
+		}
+		resolved.URL = url
+	}
+
+	if email := target.Email; email != nil {
+		resolved.SMTPServer = email.SMTPServer
+		resolved.From = email.From
+		resolved.To = email.To
+		if email.CredentialsSecretName != "" {
+			var err error
+			resolved.Username, err = r.getSecretValue(ctx, namespace, email.CredentialsSecretName, "username")
+			if err != nil {
+				return nil, err
+			}
+			resolved.Password, err = r.getSecretValue(ctx, namespace, email.CredentialsSecretName, "password")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &resolved, nil
+}
+
+// Sends the notification to a notifier's target. Returns its delivery status.
+func (r *CnfCertificationSuiteRunReconciler) sendNotification(ctx context.Context, notifier *cnfcertificationsv1alpha1.CnfCertificationNotifier,
+	target *cnfcertificationsv1alpha1.NotifierTarget, msg *notification.Message, renderErr error) cnfcertificationsv1alpha1.NotificationStatus {
+	status := cnfcertificationsv1alpha1.NotificationStatus{Notifier: notifier.Name, Target: target.Name}
+
+	err := renderErr
+	if err == nil {
+		var resolved *notification.Target
+		resolved, err = r.resolveNotifierTarget(ctx, notifier.Namespace, target)
+		if err == nil {
+			var sender notification.Sender
+			sender, err = notification.NewSender(resolved)
+			if err == nil {
+				maxAttempts := notifier.Spec.MaxAttempts
+				if maxAttempts <= 0 {
+					maxAttempts = defaultNotificationMaxAttempts
+				}
+				status.Attempts, err = notification.Deliver(ctx, sender, msg, maxAttempts, notificationRetryBackoff)
+			}
+		}
+	}
+
+	status.Time = metav1.Now()
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Delivered = true
+	}
+
+	return status
+}
+
+// Sends the notifications of the finished run to the targets of the notifiers that apply to it,
+// in the run's namespace, and records their delivery status in the run CR.
+func (r *CnfCertificationSuiteRunReconciler) notifyRunCompletion(ctx context.Context, runCrNamespacedName types.NamespacedName) {
+	runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	err := r.Get(ctx, runCrNamespacedName, &runCR)
+	if err != nil {
+		logger.Errorf("Failed to get CR %s to send its notifications: %v", runCrNamespacedName, err)
+		return
+	}
+
+	notifiers := cnfcertificationsv1alpha1.CnfCertificationNotifierList{}
+	err = r.List(ctx, &notifiers, client.InNamespace(runCR.Namespace))
+	if err != nil {
+		logger.Errorf("Failed to list CnfCertificationNotifiers (ns %s): %v", runCR.Namespace, err)
+		return
+	}
+
+	msg := notification.NewMessage(&runCR)
+	statuses := []cnfcertificationsv1alpha1.NotificationStatus{}
+	for i := range notifiers.Items {
+		notifier := &notifiers.Items[i]
+		matches, err := notifierMatchesRun(notifier, &runCR, msg.Verdict)
+		if err != nil {
+			logger.Errorf("Failed to check CnfCertificationNotifier %s: %v", notifier.Name, err)
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		renderErr := msg.Render(notifier.Spec.MessageTemplate)
+		for j := range notifier.Spec.Targets {
+			status := r.sendNotification(ctx, notifier, &notifier.Spec.Targets[j], msg, renderErr)
+			if !status.Delivered {
+				logger.Errorf("Failed to notify CR %s to target %s of notifier %s: %s", runCrNamespacedName, status.Target, notifier.Name, status.Error)
+				r.Recorder.Eventf(&runCR, corev1.EventTypeWarning, eventReasonNotificationFailed,
+					"Failed to notify target %s of CnfCertificationNotifier %s: %s", status.Target, notifier.Name, status.Error)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	if len(statuses) == 0 {
+		return
+	}
+
+	err = r.updateStatus(runCrNamespacedName, func(status *cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus) {
+		status.Notifications = statuses
+	})
+	if err != nil {
+		logger.Errorf("Failed to set the notifications' status of CR %s: %v", runCrNamespacedName, err)
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCnfCertificationSuiteRunReconciler_notifyRunCompletion(t *testing.T) {
+	notificationRetryBackoff = time.Millisecond
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request, so it's retried.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	runCrNamespacedName := types.NamespacedName{Name: "cnf-run", Namespace: "cnf-ns"}
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: runCrNamespacedName.Name, Namespace: runCrNamespacedName.Namespace, Labels: map[string]string{"release": "true"}},
+		Status: cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{
+			Phase:  cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+			Report: &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{Verdict: cnfcertificationsv1alpha1.StatusVerdictFail},
+		},
+	}
+	urlSecret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "slack-webhook", Namespace: "cnf-ns"},
+		Data:       map[string][]byte{"url": []byte(server.URL)},
+	}
+	releaseNotifier := &cnfcertificationsv1alpha1.CnfCertificationNotifier{
+		ObjectMeta: v1.ObjectMeta{Name: "release", Namespace: "cnf-ns"},
+		Spec: cnfcertificationsv1alpha1.CnfCertificationNotifierSpec{
+			RunSelector: &v1.LabelSelector{MatchLabels: map[string]string{"release": "true"}},
+			Targets: []cnfcertificationsv1alpha1.NotifierTarget{
+				{
+					Name:         "slack",
+					Type:         cnfcertificationsv1alpha1.NotifierTargetTypeSlack,
+					URLSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack-webhook"}, Key: "url"},
+				},
+				{
+					Name:         "missing-secret",
+					Type:         cnfcertificationsv1alpha1.NotifierTargetTypeWebhook,
+					URLSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "not-found"}, Key: "url"},
+				},
+			},
+		},
+	}
+	passOnlyNotifier := &cnfcertificationsv1alpha1.CnfCertificationNotifier{
+		ObjectMeta: v1.ObjectMeta{Name: "pass-only", Namespace: "cnf-ns"},
+		Spec: cnfcertificationsv1alpha1.CnfCertificationNotifierSpec{
+			Verdicts: []cnfcertificationsv1alpha1.NotifierVerdict{cnfcertificationsv1alpha1.StatusVerdictPass},
+			Targets:  []cnfcertificationsv1alpha1.NotifierTarget{{Name: "webhook", Type: cnfcertificationsv1alpha1.NotifierTargetTypeWebhook, URL: server.URL}},
+		},
+	}
+
+	r := mockReconciler([]runtime.Object{runCR, urlSecret, releaseNotifier, passOnlyNotifier})
+	r.notifyRunCompletion(context.TODO(), runCrNamespacedName)
+
+	updatedRunCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+	assert.Nil(t, r.Get(context.TODO(), runCrNamespacedName, &updatedRunCR))
+
+	// The pass-only notifier doesn't apply to the failed run.
+	notifications := updatedRunCR.Status.Notifications
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, "slack", notifications[0].Target)
+	assert.True(t, notifications[0].Delivered)
+	assert.Equal(t, 2, notifications[0].Attempts)
+	assert.Equal(t, "missing-secret", notifications[1].Target)
+	assert.False(t, notifications[1].Delivered)
+	assert.Contains(t, notifications[1].Error, "not-found")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get
+
+// Checks that the run CR's offline certification database is present, and returns its source for
+// the job pod, which must be in the job's namespace:
+//   - Persistent volume claims must exist in the job's namespace, as they can't be copied.
+//   - Config maps must have the keys of their items. If the job's namespace is not the run CR's
+//     one, the config map is copied there.
+func (r *CnfCertificationSuiteRunReconciler) setUpJobOfflineDB(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (*cnfcertificationsv1alpha1.OfflineDB, error) {
+	offlineDB := runCR.Spec.OfflineDB
+	switch {
+	case offlineDB == nil:
+		return nil, nil
+	case offlineDB.PersistentVolumeClaim != nil:
+		claimName := offlineDB.PersistentVolumeClaim.ClaimName
+		pvc := corev1.PersistentVolumeClaim{}
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: claimName, Namespace: jobNamespace}, &pvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get offline db's persistent volume claim %s (ns %s): %w", claimName, jobNamespace, err)
+		}
+		if pvc.Status.Phase == corev1.ClaimLost {
+			return nil, fmt.Errorf("offline db's persistent volume claim %s (ns %s) lost its volume", claimName, jobNamespace)
+		}
+		return offlineDB, nil
+	case offlineDB.ConfigMap != nil:
+		return r.setUpJobOfflineDBConfigMap(ctx, runCR, jobNamespace)
+	case offlineDB.Image != nil:
+		if offlineDB.Image.Image == "" {
+			return nil, fmt.Errorf("offline db's image is empty")
+		}
+		return offlineDB, nil
+	default:
+		return nil, fmt.Errorf("offline db has no source")
+	}
+}
+
+func (r *CnfCertificationSuiteRunReconciler) setUpJobOfflineDBConfigMap(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (*cnfcertificationsv1alpha1.OfflineDB, error) {
+	offlineDB := runCR.Spec.OfflineDB.DeepCopy()
+	configMapName := offlineDB.ConfigMap.Name
+
+	configMap := corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: runCR.Namespace}, &configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offline db's config map %s (ns %s): %w", configMapName, runCR.Namespace, err)
+	}
+
+	if len(configMap.Data) == 0 && len(configMap.BinaryData) == 0 {
+		return nil, fmt.Errorf("offline db's config map %s (ns %s) is empty", configMapName, runCR.Namespace)
+	}
+	for _, item := range offlineDB.ConfigMap.Items {
+		_, found := configMap.Data[item.Key]
+		if _, foundBinary := configMap.BinaryData[item.Key]; !found && !foundBinary {
+			return nil, fmt.Errorf("key %q not found in offline db's config map %s (ns %s)", item.Key, configMapName, runCR.Namespace)
+		}
+	}
+
+	if jobNamespace == runCR.Namespace {
+		return offlineDB, nil
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	configMapCopy := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getRunResourcesName(runCrNamespacedName) + "-offline-db",
+			Namespace:   jobNamespace,
+			Labels:      getRunLabels(runCrNamespacedName),
+			Annotations: getRunAnnotations(runCrNamespacedName),
+		},
+		Data:       configMap.Data,
+		BinaryData: configMap.BinaryData,
+	}
+	err = r.createOrUpdateRunResource(ctx, &configMapCopy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy offline db's config map %s to namespace %s: %w", configMapName, jobNamespace, err)
+	}
+
+	offlineDB.ConfigMap.Name = configMapCopy.Name
+	return offlineDB, nil
+}
+
+// Returns the exit status and an error in case the init container copying the offline db from its
+// image failed, so the suites never started. Images without the cp command, like scratch-based
+// images or OCI artifacts, can't be used.
+func getOfflineDBInitContainerExitStatus(certSuitePod *corev1.Pod) (int32, error) {
+	for i := range certSuitePod.Status.InitContainerStatuses {
+		containerStatus := &certSuitePod.Status.InitContainerStatuses[i]
+		if containerStatus.Name != definitions.OfflineDBInitContainerName {
+			continue
+		}
+
+		terminated := containerStatus.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			return 0, nil
+		}
+		return terminated.ExitCode, fmt.Errorf("failed to copy the offline db from its image, which must have the cp command and the db in its path "+
+			"(exit code %d, %s: %s)", terminated.ExitCode, terminated.Reason, strings.TrimSpace(terminated.Message))
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCnfCertificationSuiteRunReconciler_setUpJobOfflineDB(t *testing.T) {
+	boundPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "offline-db", Namespace: "cnf-ns"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	lostPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "lost-offline-db", Namespace: "cnf-ns"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost},
+	}
+	dbConfigMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "offline-db", Namespace: "cnf-ns"},
+		BinaryData: map[string][]byte{"containers.db": []byte("db")},
+	}
+	emptyConfigMap := &corev1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "empty", Namespace: "cnf-ns"}}
+	// Copy left by a previous attempt to deploy the run.
+	staleConfigMapCopy := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "cnf-ns-cnf-run-2a747e5d-offline-db", Namespace: "cnf-jobs"},
+		BinaryData: map[string][]byte{"containers.db": []byte("stale db")},
+	}
+
+	tests := []struct {
+		name          string
+		offlineDB     *cnfcertificationsv1alpha1.OfflineDB
+		jobNamespace  string
+		existing      []runtime.Object
+		wantErr       bool
+		wantConfigMap string
+	}{
+		{
+			name:         "No offline db",
+			jobNamespace: "cnf-ns",
+		},
+		{
+			name:         "Bound persistent volume claim",
+			offlineDB:    &cnfcertificationsv1alpha1.OfflineDB{PersistentVolumeClaim: &cnfcertificationsv1alpha1.OfflineDBVolumeClaim{ClaimName: "offline-db"}},
+			jobNamespace: "cnf-ns",
+		},
+		{
+			name:         "Persistent volume claim in another namespace",
+			offlineDB:    &cnfcertificationsv1alpha1.OfflineDB{PersistentVolumeClaim: &cnfcertificationsv1alpha1.OfflineDBVolumeClaim{ClaimName: "offline-db"}},
+			jobNamespace: "cnf-jobs",
+			wantErr:      true,
+		},
+		{
+			name:         "Lost persistent volume claim",
+			offlineDB:    &cnfcertificationsv1alpha1.OfflineDB{PersistentVolumeClaim: &cnfcertificationsv1alpha1.OfflineDBVolumeClaim{ClaimName: "lost-offline-db"}},
+			jobNamespace: "cnf-ns",
+			wantErr:      true,
+		},
+		{
+			name: "Config map with the items' keys",
+			offlineDB: &cnfcertificationsv1alpha1.OfflineDB{ConfigMap: &cnfcertificationsv1alpha1.OfflineDBConfigMap{
+				Name:  "offline-db",
+				Items: []corev1.KeyToPath{{Key: "containers.db", Path: "data/containers/containers.db"}},
+			}},
+			jobNamespace:  "cnf-ns",
+			wantConfigMap: "offline-db",
+		},
+		{
+			name: "Config map without the items' keys",
+			offlineDB: &cnfcertificationsv1alpha1.OfflineDB{ConfigMap: &cnfcertificationsv1alpha1.OfflineDBConfigMap{
+				Name:  "offline-db",
+				Items: []corev1.KeyToPath{{Key: "helm.db", Path: "data/helm/helm.db"}},
+			}},
+			jobNamespace: "cnf-ns",
+			wantErr:      true,
+		},
+		{
+			name:         "Empty config map",
+			offlineDB:    &cnfcertificationsv1alpha1.OfflineDB{ConfigMap: &cnfcertificationsv1alpha1.OfflineDBConfigMap{Name: "empty"}},
+			jobNamespace: "cnf-ns",
+			wantErr:      true,
+		},
+		{
+			name:          "Config map copied to the job's namespace",
+			offlineDB:     &cnfcertificationsv1alpha1.OfflineDB{ConfigMap: &cnfcertificationsv1alpha1.OfflineDBConfigMap{Name: "offline-db"}},
+			jobNamespace:  "cnf-jobs",
+			wantConfigMap: "cnf-ns-cnf-run-2a747e5d-offline-db",
+		},
+		{
+			name:          "Config map copy updated in the job's namespace",
+			offlineDB:     &cnfcertificationsv1alpha1.OfflineDB{ConfigMap: &cnfcertificationsv1alpha1.OfflineDBConfigMap{Name: "offline-db"}},
+			jobNamespace:  "cnf-jobs",
+			existing:      []runtime.Object{staleConfigMapCopy},
+			wantConfigMap: "cnf-ns-cnf-run-2a747e5d-offline-db",
+		},
+		{
+			name:         "Image",
+			offlineDB:    &cnfcertificationsv1alpha1.OfflineDB{Image: &cnfcertificationsv1alpha1.OfflineDBImage{Image: "registry.example.com/offline-db:latest"}},
+			jobNamespace: "cnf-ns",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+				ObjectMeta: v1.ObjectMeta{Name: "cnf-run", Namespace: "cnf-ns"},
+				Spec:       cnfcertificationsv1alpha1.CnfCertificationSuiteRunSpec{OfflineDB: tc.offlineDB},
+			}
+			r := mockReconciler(append([]runtime.Object{runCR, boundPVC, lostPVC, dbConfigMap, emptyConfigMap}, tc.existing...))
+			r.APIReader = r.Client
+
+			offlineDB, err := r.setUpJobOfflineDB(context.TODO(), runCR, tc.jobNamespace)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			if tc.offlineDB == nil {
+				assert.Nil(t, offlineDB)
+				return
+			}
+			if tc.wantConfigMap == "" {
+				assert.Equal(t, tc.offlineDB, offlineDB)
+				return
+			}
+
+			assert.Equal(t, tc.wantConfigMap, offlineDB.ConfigMap.Name)
+			assert.Equal(t, tc.offlineDB.ConfigMap.Items, offlineDB.ConfigMap.Items)
+			configMap := corev1.ConfigMap{}
+			err = r.Get(context.TODO(), types.NamespacedName{Name: tc.wantConfigMap, Namespace: tc.jobNamespace}, &configMap)
+			assert.Nil(t, err)
+			assert.Equal(t, dbConfigMap.BinaryData, configMap.BinaryData)
+		})
+	}
+}
+
+func Test_getOfflineDBInitContainerExitStatus(t *testing.T) {
+	newPod := func(state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: definitions.OfflineDBInitContainerName, State: state}},
+		}}
+	}
+
+	tests := []struct {
+		name           string
+		certSuitePod   *corev1.Pod
+		wantExitStatus int32
+		wantErr        bool
+	}{
+		{
+			name:         "Pod without offline db init container",
+			certSuitePod: &corev1.Pod{},
+		},
+		{
+			name:         "Offline db copied",
+			certSuitePod: newPod(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}),
+		},
+		{
+			name: "Image without the cp command",
+			certSuitePod: newPod(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 128,
+				Reason:   "StartError",
+				Message:  "exec: \"cp\": executable file not found in $PATH",
+			}}),
+			wantExitStatus: 128,
+			wantErr:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			exitStatus, err := getOfflineDBInitContainerExitStatus(tc.certSuitePod)
+			assert.Equal(t, tc.wantExitStatus, exitStatus)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "must have the cp command")
+				assert.ErrorContains(t, err, "StartError")
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
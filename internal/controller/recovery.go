@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+// runRecoverer resumes the runs that were deploying or running when the controller stopped, as
+// the reconciler only handles new and queued runs. It runs once, in the leader controller.
+type runRecoverer struct {
+	reconciler *CnfCertificationSuiteRunReconciler
+}
+
+// Start implements the manager's Runnable interface.
+func (rr *runRecoverer) Start(ctx context.Context) error {
+	r := rr.reconciler
+
+	runs := cnfcertificationsv1alpha1.CnfCertificationSuiteRunList{}
+	err := r.APIReader.List(ctx, &runs)
+	if err != nil {
+		logger.Errorf("Failed to list the CnfCertificationSuiteRuns to recover: %v", err)
+		return nil
+	}
+
+	for i := range runs.Items {
+		runCR := &runs.Items[i]
+		if !isRunActive(runCR) || !runCR.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		certSuitePod, err := r.recoverRun(ctx, runCR)
+		if err != nil {
+			logger.Errorf("Failed to recover CnfCertificationSuiteRun %s/%s: %v", runCR.Namespace, runCR.Name, err)
+			continue
+		}
+		if certSuitePod == nil {
+			continue
+		}
+
+		// The run's timeout started when its pod was created.
+		timeout := getJobRunTimeThreshold(runCR.Spec.TimeOut) - time.Since(certSuitePod.CreationTimestamp.Time)
+		go r.handleEndOfCnfCertSuiteRun(types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace},
+			types.NamespacedName{Name: certSuitePod.Name, Namespace: certSuitePod.Namespace}, timeout.String())
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements the manager's LeaderElectionRunnable interface.
+func (rr *runRecoverer) NeedLeaderElection() bool {
+	return true
+}
+
+// Returns true if the run is deploying or running its job pod.
+func isRunActive(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) bool {
+	return runCR.Status.Phase == cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying ||
+		runCR.Status.Phase == cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning
+}
+
+// Looks for the job pod of a run that was deploying or running when the controller stopped, and
+// returns it so that the end of the run is handled again. Without its pod, the run can't finish,
+// so it's set to the error phase and its resources are removed, and nil is returned.
+func (r *CnfCertificationSuiteRunReconciler) recoverRun(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) (*corev1.Pod, error) {
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	jobNamespace := getJobNamespace(runCR)
+
+	pods := corev1.PodList{}
+	err := r.APIReader.List(ctx, &pods, client.InNamespace(jobNamespace), client.MatchingLabels(getRunLabels(runCrNamespacedName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the job pods in namespace %s: %w", jobNamespace, err)
+	}
+
+	var certSuitePod *corev1.Pod
+	for i := range pods.Items {
+		// The pod's name is only set in the status once the run is running.
+		if runCR.Status.CnfCertSuitePodName == nil || pods.Items[i].Name == *runCR.Status.CnfCertSuitePodName {
+			certSuitePod = &pods.Items[i]
+			break
+		}
+	}
+
+	if certSuitePod == nil {
+		logger.Infof("CNF Cert job pod of CR %s not found after the controller restarted.", runCrNamespacedName)
+		r.recordRunEvent(runCrNamespacedName, corev1.EventTypeWarning, eventReasonPodLost,
+			"CNF Cert job pod not found in namespace %s after the controller restarted", jobNamespace)
+		err = r.updateStatusPhase(runCrNamespacedName, cnfcertificationsv1alpha1.StatusPhaseCertSuiteError)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set status field Phase %s: %w", cnfcertificationsv1alpha1.StatusPhaseCertSuiteError, err)
+		}
+
+		r.cleanUpRunResources(ctx, runCrNamespacedName, jobNamespace)
+		notifyCtx, cancel := context.WithTimeout(ctx, notificationsTimeout)
+		defer cancel()
+		r.notifyRunCompletion(notifyCtx, runCrNamespacedName)
+		return nil, nil
+	}
+
+	logger.Infof("Resuming CNF Cert job pod %s of CR %s after the controller restarted.", certSuitePod.Name, runCrNamespacedName)
+	if runCR.Status.Phase == cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying {
+		err = r.updateStatus(runCrNamespacedName, func(status *cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus) {
+			status.Phase = cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning
+			status.CnfCertSuitePodName = &certSuitePod.Name
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set status field Phase %s and podName %s: %w",
+				cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, certSuitePod.Name, err)
+		}
+	}
+
+	return certSuitePod, nil
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func newRecoveryRunCR(name string, phase cnfcertificationsv1alpha1.StatusPhase, podName string) *cnfcertificationsv1alpha1.CnfCertificationSuiteRun {
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "cnf-ns"},
+		Status:     cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{Phase: phase},
+	}
+	if podName != "" {
+		runCR.Status.CnfCertSuitePodName = &podName
+	}
+	return runCR
+}
+
+func newRecoveryJobPod(name, runName string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: v1.ObjectMeta{
+		Name:      name,
+		Namespace: "cnf-ns",
+		Labels:    getRunLabels(types.NamespacedName{Name: runName, Namespace: "cnf-ns"}),
+	}}
+}
+
+func TestCnfCertificationSuiteRunReconciler_recoverRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		runCR       *cnfcertificationsv1alpha1.CnfCertificationSuiteRun
+		pods        []runtime.Object
+		wantPodName string
+		wantPhase   cnfcertificationsv1alpha1.StatusPhase
+		wantEvent   string
+	}{
+		{
+			name:        "Running run with its pod",
+			runCR:       newRecoveryRunCR("cnf-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, "cnf-job-run-2"),
+			pods:        []runtime.Object{newRecoveryJobPod("cnf-job-run-1", "other-run"), newRecoveryJobPod("cnf-job-run-2", "cnf-run")},
+			wantPodName: "cnf-job-run-2",
+			wantPhase:   cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning,
+		},
+		{
+			name:        "Deploying run with its pod",
+			runCR:       newRecoveryRunCR("cnf-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying, ""),
+			pods:        []runtime.Object{newRecoveryJobPod("cnf-job-run-1", "cnf-run")},
+			wantPodName: "cnf-job-run-1",
+			wantPhase:   cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning,
+		},
+		{
+			name:      "Running run without its pod",
+			runCR:     newRecoveryRunCR("cnf-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, "cnf-job-run-2"),
+			pods:      []runtime.Object{newRecoveryJobPod("cnf-job-run-1", "other-run")},
+			wantPhase: cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+			wantEvent: "Warning PodLost CNF Cert job pod not found in namespace cnf-ns after the controller restarted",
+		},
+		{
+			name:      "Deploying run without pod",
+			runCR:     newRecoveryRunCR("cnf-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying, ""),
+			wantPhase: cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+			wantEvent: "Warning PodLost CNF Cert job pod not found in namespace cnf-ns after the controller restarted",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mockReconciler(append(tc.pods, tc.runCR))
+			r.APIReader = r.Client
+			recorder := r.Recorder.(*record.FakeRecorder)
+
+			certSuitePod, err := r.recoverRun(context.TODO(), tc.runCR)
+			assert.Nil(t, err)
+			if tc.wantPodName == "" {
+				assert.Nil(t, certSuitePod)
+			} else if assert.NotNil(t, certSuitePod) {
+				assert.Equal(t, tc.wantPodName, certSuitePod.Name)
+			}
+
+			runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+			assert.Nil(t, r.Get(context.TODO(), types.NamespacedName{Name: tc.runCR.Name, Namespace: tc.runCR.Namespace}, &runCR))
+			assert.Equal(t, tc.wantPhase, runCR.Status.Phase)
+			if tc.wantPodName != "" && assert.NotNil(t, runCR.Status.CnfCertSuitePodName) {
+				assert.Equal(t, tc.wantPodName, *runCR.Status.CnfCertSuitePodName)
+			}
+
+			if tc.wantEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				assert.Equal(t, tc.wantEvent, <-recorder.Events)
+			}
+		})
+	}
+}
+
+func TestRunRecoverer_Start(t *testing.T) {
+	finishedRun := newRecoveryRunCR("finished-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, "cnf-job-run-1")
+	queuedRun := newRecoveryRunCR("queued-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued, "")
+	lostRun := newRecoveryRunCR("lost-run", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, "cnf-job-run-2")
+
+	r := mockReconciler([]runtime.Object{finishedRun, queuedRun, lostRun})
+	r.APIReader = r.Client
+
+	rr := runRecoverer{reconciler: r}
+	assert.Nil(t, rr.Start(context.TODO()))
+	assert.True(t, rr.NeedLeaderElection())
+
+	// Only the active runs are recovered.
+	for runName, wantPhase := range map[string]cnfcertificationsv1alpha1.StatusPhase{
+		"finished-run": cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+		"queued-run":   cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued,
+		"lost-run":     cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+	} {
+		runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{}
+		assert.Nil(t, r.Get(context.TODO(), types.NamespacedName{Name: runName, Namespace: "cnf-ns"}, &runCR))
+		assert.Equal(t, wantPhase, runCR.Status.Phase, runName)
+	}
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// Interval between the removals of the finished runs that are not retained anymore.
+const runRetentionCheckInterval = 10 * time.Minute
+
+// retentionPolicy holds the limits of the finished runs kept by the controller. Zero values mean
+// no limit.
+type retentionPolicy struct {
+	// Max number of finished runs kept. The oldest ones are removed first.
+	maxRuns int
+	// Max age of the finished runs.
+	maxAge time.Duration
+	// Max number of finished runs kept for the same target namespaces.
+	keepLastPerTarget int
+	// Max age of the failed runs, used instead of maxAge so they can be kept longer.
+	failedMaxAge time.Duration
+}
+
+func (p *retentionPolicy) isEnabled() bool {
+	return p.maxRuns > 0 || p.maxAge > 0 || p.keepLastPerTarget > 0 || p.failedMaxAge > 0
+}
+
+// runRetentionEntry holds the info of a finished run needed to decide whether it's retained.
+type runRetentionEntry struct {
+	namespacedName types.NamespacedName
+	creationTime   metav1.Time
+	failed         bool
+	// Sorted target namespaces of the run, empty if unknown.
+	target string
+}
+
+// Returns the finished runs that exceed the retention policy, in no specific order:
+//   - Runs older than the max age, or than the failed runs' max age if they failed and it's set.
+//   - Runs older than the newest keepLastPerTarget runs of the same target namespaces.
+//   - Runs older than the newest maxRuns runs.
+func getExpiredRuns(runs []runRetentionEntry, policy *retentionPolicy, now time.Time) []types.NamespacedName {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].creationTime.Equal(&runs[j].creationTime) {
+			return runs[j].creationTime.Before(&runs[i].creationTime)
+		}
+		return runs[i].namespacedName.String() < runs[j].namespacedName.String()
+	})
+
+	expiredRuns := []types.NamespacedName{}
+	keptRuns := 0
+	keptRunsByTarget := map[string]int{}
+	for _, run := range runs {
+		maxAge := policy.maxAge
+		if run.failed && policy.failedMaxAge > 0 {
+			maxAge = policy.failedMaxAge
+		}
+
+		expired := maxAge > 0 && now.Sub(run.creationTime.Time) > maxAge
+		if !expired && policy.keepLastPerTarget > 0 && run.target != "" {
+			expired = keptRunsByTarget[run.target] >= policy.keepLastPerTarget
+		}
+		if !expired && policy.maxRuns > 0 {
+			expired = keptRuns >= policy.maxRuns
+		}
+
+		if expired {
+			expiredRuns = append(expiredRuns, run.namespacedName)
+			continue
+		}
+		keptRuns++
+		keptRunsByTarget[run.target]++
+	}
+
+	return expiredRuns
+}
+
+func isRunFinished(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) bool {
+	switch runCR.Status.Phase {
+	case cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, cnfcertificationsv1alpha1.StatusPhaseCertSuiteError,
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError:
+		return true
+	}
+	return false
+}
+
+func isRunFailed(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) bool {
+	if runCR.Status.Phase != cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished {
+		return true
+	}
+	report := runCR.Status.Report
+	return report != nil && (report.Verdict == cnfcertificationsv1alpha1.StatusVerdictFail || report.Verdict == cnfcertificationsv1alpha1.StatusVerdictError)
+}
+
+// Returns true if the run must never be removed by the retention policy.
+func isRunPinned(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) bool {
+	return runCR.Annotations[definitions.RunPinAnnotation] == "true" || runCR.Annotations[definitions.RunProtectAnnotation] == "true"
+}
+
+// Returns the run's retention entry, with the target namespaces of its config. Runs whose config
+// can't be read anymore have no target, so they only count for the max runs.
+func (r *CnfCertificationSuiteRunReconciler) getRunRetentionEntry(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) runRetentionEntry {
+	entry := runRetentionEntry{
+		namespacedName: types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace},
+		creationTime:   runCR.CreationTimestamp,
+		failed:         isRunFailed(runCR),
+	}
+
+	config, err := r.getRunCertSuiteConfig(ctx, runCR)
+	if err != nil {
+		logger.Infof("Target namespaces of CR %s are unknown: %v", entry.namespacedName, err)
+		return entry
+	}
+
+	targetNamespaces := getTargetNamespaces(config)
+	sort.Strings(targetNamespaces)
+	entry.target = strings.Join(targetNamespaces, ",")
+	return entry
+}
+
+// Removes the finished runs that exceed the retention policy, along with their resources. Pinned
+// and protected runs are never removed, and don't count for the policy's limits.
+func (r *CnfCertificationSuiteRunReconciler) removeExpiredRuns(ctx context.Context, policy *retentionPolicy) error {
+	runs := cnfcertificationsv1alpha1.CnfCertificationSuiteRunList{}
+	err := r.List(ctx, &runs)
+	if err != nil {
+		return fmt.Errorf("failed to list CnfCertificationSuiteRuns: %w", err)
+	}
+
+	entries := []runRetentionEntry{}
+	for i := range runs.Items {
+		run := &runs.Items[i]
+		if !isRunFinished(run) || isRunPinned(run) || !run.DeletionTimestamp.IsZero() {
+			continue
+		}
+		entries = append(entries, r.getRunRetentionEntry(ctx, run))
+	}
+
+	for _, runCrNamespacedName := range getExpiredRuns(entries, policy, time.Now()) {
+		logger.Infof("Removing CnfCertificationSuiteRun %s, as it exceeds the retention policy.", runCrNamespacedName)
+		runCR := cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+			ObjectMeta: metav1.ObjectMeta{Name: runCrNamespacedName.Name, Namespace: runCrNamespacedName.Namespace},
+		}
+		err = r.Delete(ctx, &runCR)
+		if client.IgnoreNotFound(err) != nil {
+			logger.Errorf("Failed to remove CnfCertificationSuiteRun %s: %v", runCrNamespacedName, err)
+		}
+	}
+
+	return nil
+}
+
+// runGarbageCollector periodically removes the finished runs that exceed the retention policy. It
+// only runs in the leader controller.
+type runGarbageCollector struct {
+	reconciler *CnfCertificationSuiteRunReconciler
+	policy     retentionPolicy
+}
+
+// Start implements the manager's Runnable interface.
+func (gc *runGarbageCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(runRetentionCheckInterval)
+	defer ticker.Stop()
+	for {
+		err := gc.reconciler.removeExpiredRuns(ctx, &gc.policy)
+		if err != nil {
+			logger.Errorf("Failed to remove the runs exceeding the retention policy: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager's LeaderElectionRunnable interface.
+func (gc *runGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Returns the retention policy set in the controller's environment variables.
+func getRetentionPolicyFromEnv() (*retentionPolicy, error) {
+	policy := retentionPolicy{}
+	for envVar, value := range map[string]*int{
+		definitions.RunRetentionMaxRunsEnvVar:           &policy.maxRuns,
+		definitions.RunRetentionKeepLastPerTargetEnvVar: &policy.keepLastPerTarget,
+	} {
+		valueStr := os.Getenv(envVar)
+		if valueStr == "" {
+			continue
+		}
+		var err error
+		*value, err = strconv.Atoi(valueStr)
+		if err != nil || *value < 0 {
+			return nil, fmt.Errorf("invalid number in env var %q: %q", envVar, valueStr)
+		}
+	}
+
+	for envVar, value := range map[string]*time.Duration{
+		definitions.RunRetentionMaxAgeEnvVar:       &policy.maxAge,
+		definitions.RunRetentionFailedMaxAgeEnvVar: &policy.failedMaxAge,
+	} {
+		valueStr := os.Getenv(envVar)
+		if valueStr == "" {
+			continue
+		}
+		var err error
+		*value, err = time.ParseDuration(valueStr)
+		if err != nil || *value < 0 {
+			return nil, fmt.Errorf("invalid duration in env var %q: %q", envVar, valueStr)
+		}
+	}
+
+	return &policy, nil
+}
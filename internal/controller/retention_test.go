@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newRunRetentionEntry(name string, createdHoursAgo int, failed bool, target string) runRetentionEntry {
+	return runRetentionEntry{
+		namespacedName: types.NamespacedName{Name: name, Namespace: "cnf-ns"},
+		creationTime:   v1.NewTime(time.Now().Add(-time.Duration(createdHoursAgo) * time.Hour)),
+		failed:         failed,
+		target:         target,
+	}
+}
+
+func Test_getExpiredRuns(t *testing.T) {
+	tests := []struct {
+		name   string
+		runs   []runRetentionEntry
+		policy retentionPolicy
+		want   []string
+	}{
+		{ // Test case #1 - No limits
+			name:   "Runs are kept when there are no limits",
+			runs:   []runRetentionEntry{newRunRetentionEntry("run1", 1000, false, "tnf")},
+			policy: retentionPolicy{},
+			want:   []string{},
+		},
+		{ // Test case #2 - Max age
+			name: "Runs older than the max age are removed",
+			runs: []runRetentionEntry{
+				newRunRetentionEntry("run1", 48, false, "tnf"),
+				newRunRetentionEntry("run2", 1, false, "tnf"),
+			},
+			policy: retentionPolicy{maxAge: 24 * time.Hour},
+			want:   []string{"run1"},
+		},
+		{ // Test case #3 - Failed runs kept longer
+			name: "Failed runs older than the max age are kept until the failed runs' max age",
+			runs: []runRetentionEntry{
+				newRunRetentionEntry("run1", 48, true, "tnf"),
+				newRunRetentionEntry("run2", 48, false, "tnf"),
+				newRunRetentionEntry("run3", 200, true, "tnf"),
+			},
+			policy: retentionPolicy{maxAge: 24 * time.Hour, failedMaxAge: 7 * 24 * time.Hour},
+			want:   []string{"run2", "run3"},
+		},
+		{ // Test case #4 - Keep last per target
+			name: "Only the newest runs of every target are kept",
+			runs: []runRetentionEntry{
+				newRunRetentionEntry("run1", 3, false, "ns1"),
+				newRunRetentionEntry("run2", 2, false, "ns1"),
+				newRunRetentionEntry("run3", 1, false, "ns1"),
+				newRunRetentionEntry("run4", 5, false, "ns2"),
+				newRunRetentionEntry("run5", 6, false, ""),
+				newRunRetentionEntry("run6", 7, false, ""),
+			},
+			policy: retentionPolicy{keepLastPerTarget: 2},
+			want:   []string{"run1"},
+		},
+		{ // Test case #5 - Max runs
+			name: "Only the newest runs are kept",
+			runs: []runRetentionEntry{
+				newRunRetentionEntry("run1", 3, false, "ns1"),
+				newRunRetentionEntry("run2", 1, false, "ns2"),
+				newRunRetentionEntry("run3", 2, true, "ns3"),
+			},
+			policy: retentionPolicy{maxRuns: 2},
+			want:   []string{"run1"},
+		},
+		{ // Test case #6 - Expired runs don't count for the max runs
+			name: "Runs removed by age leave room for older runs of other targets",
+			runs: []runRetentionEntry{
+				newRunRetentionEntry("run1", 1, false, "ns1"),
+				newRunRetentionEntry("run2", 2, false, "ns1"),
+				newRunRetentionEntry("run3", 3, false, "ns2"),
+				newRunRetentionEntry("run4", 4, false, "ns3"),
+			},
+			policy: retentionPolicy{maxRuns: 2, keepLastPerTarget: 1},
+			want:   []string{"run2", "run4"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, run := range getExpiredRuns(tc.runs, &tc.policy, time.Now()) {
+				got = append(got, run.Name)
+			}
+			assert.ElementsMatch(t, tc.want, got)
+		})
+	}
+}
+
+func TestCnfCertificationSuiteRunReconciler_removeExpiredRuns(t *testing.T) {
+	newRun := func(name string, createdHoursAgo int, phase cnfcertificationsv1alpha1.StatusPhase, annotations map[string]string) *cnfcertificationsv1alpha1.CnfCertificationSuiteRun {
+		return &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              name,
+				Namespace:         "cnf-ns",
+				CreationTimestamp: v1.NewTime(time.Now().Add(-time.Duration(createdHoursAgo) * time.Hour)),
+				Annotations:       annotations,
+			},
+			Spec: cnfcertificationsv1alpha1.CnfCertificationSuiteRunSpec{
+				Config: &cnfcertificationsv1alpha1.CnfCertSuiteConfig{
+					TargetNameSpaces: []cnfcertificationsv1alpha1.TargetNamespace{{Name: "tnf"}},
+				},
+			},
+			Status: cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{Phase: phase},
+		}
+	}
+
+	oldRun := newRun("old-run", 48, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, nil)
+	pinnedRun := newRun("pinned-run", 48, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, map[string]string{definitions.RunPinAnnotation: "true"})
+	protectedRun := newRun("protected-run", 48, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, map[string]string{definitions.RunProtectAnnotation: "true"})
+	runningRun := newRun("running-run", 48, cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, nil)
+	newestRun := newRun("newest-run", 1, cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished, nil)
+
+	r := mockReconciler([]runtime.Object{oldRun, pinnedRun, protectedRun, runningRun, newestRun})
+
+	err := r.removeExpiredRuns(context.TODO(), &retentionPolicy{maxAge: 24 * time.Hour, keepLastPerTarget: 1})
+	assert.Nil(t, err)
+
+	err = r.Get(context.TODO(), types.NamespacedName{Name: oldRun.Name, Namespace: oldRun.Namespace}, &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// Pinned, protected and unfinished runs must be kept, and not count for the target's limit.
+	for _, run := range []*cnfcertificationsv1alpha1.CnfCertificationSuiteRun{pinnedRun, protectedRun, runningRun, newestRun} {
+		err = r.Get(context.TODO(), types.NamespacedName{Name: run.Name, Namespace: run.Namespace}, &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{})
+		assert.Nil(t, err, run.Name)
+	}
+}
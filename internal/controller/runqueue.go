@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+// Interval to check whether a queued run can start.
+const queuedRunCheckInterval = 15 * time.Second
+
+// runQueueEntry holds the info of a run needed to decide when it can start.
+type runQueueEntry struct {
+	namespacedName   types.NamespacedName
+	creationTime     metav1.Time
+	priority         int32
+	targetNamespaces []string
+}
+
+// Returns whether the run can start or, otherwise, its position in the queue. Waiting runs start in
+// priority order, and in creation order for the same priority, as long as:
+//   - The number of active runs is lower than the max concurrent runs, if set.
+//   - None of their target namespaces is tested by an active run or by an older waiting run.
+func getRunQueuePosition(run types.NamespacedName, activeRuns, waitingRuns []runQueueEntry, maxRuns int) (canStart bool, position int) {
+	sort.SliceStable(waitingRuns, func(i, j int) bool {
+		if waitingRuns[i].priority != waitingRuns[j].priority {
+			return waitingRuns[i].priority > waitingRuns[j].priority
+		}
+		if !waitingRuns[i].creationTime.Equal(&waitingRuns[j].creationTime) {
+			return waitingRuns[i].creationTime.Before(&waitingRuns[j].creationTime)
+		}
+		return waitingRuns[i].namespacedName.String() < waitingRuns[j].namespacedName.String()
+	})
+
+	lockedNamespaces := map[string]bool{}
+	for _, entry := range activeRuns {
+		for _, namespace := range entry.targetNamespaces {
+			lockedNamespaces[namespace] = true
+		}
+	}
+
+	freeSlots := maxRuns - len(activeRuns)
+	if maxRuns <= 0 {
+		freeSlots = len(waitingRuns)
+	}
+
+	for _, entry := range waitingRuns {
+		startable := freeSlots > 0
+		for _, namespace := range entry.targetNamespaces {
+			if lockedNamespaces[namespace] {
+				startable = false
+			}
+			lockedNamespaces[namespace] = true
+		}
+
+		if startable {
+			freeSlots--
+		} else {
+			position++
+		}
+
+		if entry.namespacedName == run {
+			if startable {
+				return true, 0
+			}
+			return false, position
+		}
+	}
+
+	return false, position + 1
+}
+
+// Returns the run's queue entry, with the target namespaces of its config. Runs whose config can't
+// be read have no target namespaces, so they don't block other runs.
+func (r *CnfCertificationSuiteRunReconciler) getRunQueueEntry(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) runQueueEntry {
+	entry := runQueueEntry{
+		namespacedName: types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace},
+		creationTime:   runCR.CreationTimestamp,
+		priority:       runCR.Spec.Priority,
+	}
+
+	config, err := r.getRunCertSuiteConfig(ctx, runCR)
+	if err != nil {
+		logger.Errorf("Failed to get target namespaces of CR %s: %v", entry.namespacedName, err)
+		return entry
+	}
+
+	entry.targetNamespaces = getTargetNamespaces(config)
+	return entry
+}
+
+// Checks whether the run CR can start. If it can't, its phase is set to queued along with its
+// position in the queue. The runs are read from the API server, so runs that have just been
+// started are taken into account. Runs without target namespaces are never queued when the
+// number of active runs is not limited, so no runs are read for them.
+func (r *CnfCertificationSuiteRunReconciler) isRunQueued(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) (bool, error) {
+	if maxConcurrentRuns <= 0 && len(r.getRunQueueEntry(ctx, runCR).targetNamespaces) == 0 {
+		return false, nil
+	}
+
+	runs := cnfcertificationsv1alpha1.CnfCertificationSuiteRunList{}
+	err := r.APIReader.List(ctx, &runs)
+	if err != nil {
+		return false, fmt.Errorf("failed to list CnfCertificationSuiteRuns: %w", err)
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	activeRuns := []runQueueEntry{}
+	waitingRuns := []runQueueEntry{}
+	for i := range runs.Items {
+		run := &runs.Items[i]
+		isCurrentRun := run.Name == runCR.Name && run.Namespace == runCR.Namespace
+
+		switch run.Status.Phase {
+		case cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying, cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning:
+			if isCurrentRun {
+				// The cache was not in sync yet, the run has already been started.
+				logger.Infof("CnfCertificationSuiteRun %s has already been started.", runCrNamespacedName)
+				return true, nil
+			}
+			activeRuns = append(activeRuns, r.getRunQueueEntry(ctx, run))
+		case "", cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued:
+			// Only the runs that were already queued are taken into account, besides the current one,
+			// as new runs may belong to namespaces not watched by the controller.
+			if isCurrentRun || (run.Status.Phase != "" && run.DeletionTimestamp.IsZero()) {
+				waitingRuns = append(waitingRuns, r.getRunQueueEntry(ctx, run))
+			}
+		}
+	}
+
+	canStart, position := getRunQueuePosition(runCrNamespacedName, activeRuns, waitingRuns, maxConcurrentRuns)
+	if canStart {
+		return false, nil
+	}
+
+	if runCR.Status.Phase != cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued || runCR.Status.QueuePosition != position {
+		logger.Infof("CnfCertificationSuiteRun %s is queued in position %d.", runCrNamespacedName, position)
+		if runCR.Status.Phase != cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued {
+			r.Recorder.Eventf(runCR, corev1.EventTypeNormal, eventReasonQueued, "Run queued in position %d", position)
+		}
+		err = r.updateStatus(runCrNamespacedName, func(status *cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus) {
+			status.Phase = cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued
+			status.QueuePosition = position
+		})
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newRunQueueEntry(name string, createdMinutesAgo int, targetNamespaces ...string) runQueueEntry {
+	return newPriorityRunQueueEntry(name, createdMinutesAgo, 0, targetNamespaces...)
+}
+
+func newPriorityRunQueueEntry(name string, createdMinutesAgo int, priority int32, targetNamespaces ...string) runQueueEntry {
+	return runQueueEntry{
+		namespacedName:   types.NamespacedName{Name: name, Namespace: "cnf-ns"},
+		creationTime:     v1.NewTime(time.Now().Add(-time.Duration(createdMinutesAgo) * time.Minute)),
+		priority:         priority,
+		targetNamespaces: targetNamespaces,
+	}
+}
+
+func Test_getRunQueuePosition(t *testing.T) {
+	tests := []struct {
+		name         string
+		run          string
+		activeRuns   []runQueueEntry
+		waitingRuns  []runQueueEntry
+		maxRuns      int
+		wantCanStart bool
+		wantPosition int
+	}{
+		{ // Test case #1 - No limit and no active runs
+			name:         "Run starts right away",
+			run:          "run1",
+			waitingRuns:  []runQueueEntry{newRunQueueEntry("run1", 0, "tnf")},
+			wantCanStart: true,
+		},
+		{ // Test case #2 - Max concurrent runs reached
+			name:         "Run is queued when there are no free slots",
+			run:          "run2",
+			activeRuns:   []runQueueEntry{newRunQueueEntry("run1", 10, "tnf")},
+			waitingRuns:  []runQueueEntry{newRunQueueEntry("run2", 0, "other")},
+			maxRuns:      1,
+			wantPosition: 1,
+		},
+		{ // Test case #3 - Target namespace being tested by an active run
+			name:         "Run is queued when its target namespace is locked",
+			run:          "run2",
+			activeRuns:   []runQueueEntry{newRunQueueEntry("run1", 10, "tnf", "other")},
+			waitingRuns:  []runQueueEntry{newRunQueueEntry("run2", 0, "tnf")},
+			wantPosition: 1,
+		},
+		{ // Test case #4 - Older runs take the free slot first
+			name:       "Runs are started in creation order",
+			run:        "run3",
+			maxRuns:    2,
+			activeRuns: []runQueueEntry{newRunQueueEntry("run1", 10, "ns1")},
+			waitingRuns: []runQueueEntry{
+				newRunQueueEntry("run3", 1, "ns3"),
+				newRunQueueEntry("run2", 5, "ns2"),
+			},
+			wantPosition: 1,
+		},
+		{ // Test case #5 - Runs on other namespaces are not blocked by a locked one
+			name:       "Run starts when older queued runs are blocked on other namespaces",
+			run:        "run3",
+			activeRuns: []runQueueEntry{newRunQueueEntry("run1", 10, "ns1")},
+			waitingRuns: []runQueueEntry{
+				newRunQueueEntry("run2", 5, "ns1"),
+				newRunQueueEntry("run3", 1, "ns3"),
+			},
+			wantCanStart: true,
+		},
+		{ // Test case #6 - Older queued run on the same namespace goes first
+			name:       "Run waits for an older queued run on the same namespace",
+			run:        "run3",
+			activeRuns: []runQueueEntry{newRunQueueEntry("run1", 10, "ns1")},
+			waitingRuns: []runQueueEntry{
+				newRunQueueEntry("run2", 5, "ns1", "ns2"),
+				newRunQueueEntry("run3", 1, "ns2"),
+			},
+			wantPosition: 2,
+		},
+		{ // Test case #7 - Higher priority runs go before older queued runs
+			name:       "Run with higher priority takes the free slot",
+			run:        "run3",
+			maxRuns:    2,
+			activeRuns: []runQueueEntry{newRunQueueEntry("run1", 10, "ns1")},
+			waitingRuns: []runQueueEntry{
+				newRunQueueEntry("run2", 5, "ns2"),
+				newPriorityRunQueueEntry("run3", 1, 100, "ns3"),
+			},
+			wantCanStart: true,
+		},
+		{ // Test case #8 - Lower priority queued runs are moved back
+			name:       "Run with lower priority is queued after higher priority ones",
+			run:        "run2",
+			maxRuns:    1,
+			activeRuns: []runQueueEntry{newRunQueueEntry("run1", 10, "ns1")},
+			waitingRuns: []runQueueEntry{
+				newPriorityRunQueueEntry("run2", 5, -1, "ns2"),
+				newPriorityRunQueueEntry("run3", 1, 100, "ns3"),
+				newRunQueueEntry("run4", 1, "ns4"),
+			},
+			wantPosition: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		run := types.NamespacedName{Name: tc.run, Namespace: "cnf-ns"}
+		gotCanStart, gotPosition := getRunQueuePosition(run, tc.activeRuns, tc.waitingRuns, tc.maxRuns)
+		assert.Equal(t, tc.wantCanStart, gotCanStart, tc.name)
+		assert.Equal(t, tc.wantPosition, gotPosition, tc.name)
+	}
+}
+
+func newQueueRunCR(name string, phase cnfcertificationsv1alpha1.StatusPhase, targetNamespaces ...string) *cnfcertificationsv1alpha1.CnfCertificationSuiteRun {
+	runCR := &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "cnf-ns", CreationTimestamp: v1.Now()},
+		Status:     cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{Phase: phase},
+	}
+	if len(targetNamespaces) > 0 {
+		runCR.Spec.Config = &cnfcertificationsv1alpha1.CnfCertSuiteConfig{}
+		for _, namespace := range targetNamespaces {
+			runCR.Spec.Config.TargetNameSpaces = append(runCR.Spec.Config.TargetNameSpaces,
+				cnfcertificationsv1alpha1.TargetNamespace{Name: cnfcertificationsv1alpha1.CnfCertSuiteNamespace(namespace)})
+		}
+	}
+	return runCR
+}
+
+func Test_isRunQueued(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxRuns   int
+		run       *cnfcertificationsv1alpha1.CnfCertificationSuiteRun
+		otherRuns []runtime.Object
+		// Whether the runs are read from the API server.
+		listRuns   bool
+		wantQueued bool
+	}{
+		{ // Test case #1 - No limit and no targets
+			name:       "Runs without target namespaces are not queued when there's no limit",
+			run:        newQueueRunCR("run1", ""),
+			otherRuns:  []runtime.Object{newQueueRunCR("run2", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning)},
+			listRuns:   false,
+			wantQueued: false,
+		},
+		{ // Test case #2 - Target namespace locked
+			name:       "Runs testing a namespace tested by an active run are queued",
+			run:        newQueueRunCR("run1", "", "tnf"),
+			otherRuns:  []runtime.Object{newQueueRunCR("run2", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning, "tnf")},
+			listRuns:   true,
+			wantQueued: true,
+		},
+		{ // Test case #3 - Max concurrent runs reached
+			name:       "Runs without target namespaces are queued when the limit is reached",
+			maxRuns:    1,
+			run:        newQueueRunCR("run1", ""),
+			otherRuns:  []runtime.Object{newQueueRunCR("run2", cnfcertificationsv1alpha1.StatusPhaseCertSuiteRunning)},
+			listRuns:   true,
+			wantQueued: true,
+		},
+	}
+
+	defer func(maxRuns int) { maxConcurrentRuns = maxRuns }(maxConcurrentRuns)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			maxConcurrentRuns = tc.maxRuns
+			r := mockReconciler(append(tc.otherRuns, tc.run))
+			// Without an API reader, reading the runs panics.
+			if tc.listRuns {
+				r.APIReader = r.Client
+			}
+
+			queued, err := r.isRunQueued(context.TODO(), tc.run)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantQueued, queued)
+		})
+	}
+}
+
+func Test_isRunQueuedAfterDeployFailure(t *testing.T) {
+	defer func(maxRuns int) { maxConcurrentRuns = maxRuns }(maxConcurrentRuns)
+	maxConcurrentRuns = 1
+
+	failedRun := newQueueRunCR("run1", cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeploying)
+	queuedRun := newQueueRunCR("run2", cnfcertificationsv1alpha1.StatusPhaseCertSuiteQueued)
+	r := mockReconciler([]runtime.Object{failedRun, queuedRun})
+	r.APIReader = r.Client
+
+	queued, err := r.isRunQueued(context.TODO(), queuedRun)
+	assert.Nil(t, err)
+	assert.True(t, queued)
+
+	// The fake client doesn't validate the status, so make sure the API server accepts the phase.
+	assert.Contains(t, getCrdStatusPhases(t), cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError)
+	err = r.updateStatusPhase(types.NamespacedName{Name: failedRun.Name, Namespace: failedRun.Namespace},
+		cnfcertificationsv1alpha1.StatusPhaseCertSuiteDeployError)
+	assert.Nil(t, err)
+
+	queued, err = r.isRunQueued(context.TODO(), queuedRun)
+	assert.Nil(t, err)
+	assert.False(t, queued)
+}
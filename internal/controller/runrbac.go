@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+)
+
+// Binding of one of the run cluster roles in a namespace.
+type runRoleBinding struct {
+	namespace       string
+	clusterRoleName string
+	// Appended to the run's resources name, as a namespace may have several bindings of a run.
+	suffix string
+}
+
+// Maximum length of the run resources' names, leaving room for the longest suffix appended to
+// them ("-offline-db") within the 63 characters of a DNS label.
+const runResourcesNameMaxLength = 52
+
+// Returns the name used for the resources generated for a run CR. The resources may be created in
+// a namespace shared by runs from several namespaces, so the name ends with a hash of the run
+// CR's namespace and name, which are truncated before it to keep the name short enough.
+func getRunResourcesName(runCrNamespacedName types.NamespacedName) string {
+	hash := sha256.Sum256([]byte(runCrNamespacedName.String()))
+	hashSuffix := "-" + hex.EncodeToString(hash[:])[:8]
+
+	prefix := runCrNamespacedName.Namespace + "-" + runCrNamespacedName.Name
+	if maxPrefixLength := runResourcesNameMaxLength - len(hashSuffix); len(prefix) > maxPrefixLength {
+		prefix = strings.TrimRight(prefix[:maxPrefixLength], "-.")
+	}
+	return prefix + hashSuffix
+}
+
+// Returns the name of the service account to be used by the job pod of a run CR, after making
+// sure it exists:
+//   - The run CR's service account, if set.
+//   - A service account with a generated RBAC scoped to the run CR's target namespaces, if the
+//     controller is set to generate them.
+//   - The default job's service account, otherwise.
+func (r *CnfCertificationSuiteRunReconciler) setUpJobServiceAccount(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (string, error) {
+	if runCR.Spec.ServiceAccountName != "" {
+		return runCR.Spec.ServiceAccountName, nil
+	}
+
+	if generateRunRbac {
+		return r.generateRunRBAC(ctx, runCR, jobNamespace)
+	}
+
+	err := r.ensureJobServiceAccount(ctx, jobNamespace)
+	if err != nil {
+		return "", err
+	}
+	return definitions.JobServiceAccountName, nil
+}
+
+// Creates a service account for the run CR's job pod, bound to the run cluster roles installed
+// with the operator: read-only access to the cluster, access to the run's target namespaces and
+// the probe namespace, and to the run CR's namespace for the sidecar. Returns its name.
+func (r *CnfCertificationSuiteRunReconciler) generateRunRBAC(ctx context.Context, runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun, jobNamespace string) (string, error) {
+	config, err := r.getRunCertSuiteConfig(ctx, runCR)
+	if err != nil {
+		return "", err
+	}
+
+	runCrNamespacedName := types.NamespacedName{Name: runCR.Name, Namespace: runCR.Namespace}
+	name := getRunResourcesName(runCrNamespacedName)
+	objectMeta := metav1.ObjectMeta{Name: name, Labels: getRunLabels(runCrNamespacedName), Annotations: getRunAnnotations(runCrNamespacedName)}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: jobNamespace}}
+
+	logger.Infof("Generating RBAC for CR %s in namespaces %v", runCrNamespacedName, getTargetNamespaces(config))
+
+	serviceAccount := corev1.ServiceAccount{ObjectMeta: *objectMeta.DeepCopy()}
+	serviceAccount.Namespace = jobNamespace
+
+	clusterRoleBinding := rbacv1.ClusterRoleBinding{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: definitions.RunClusterReaderRoleName},
+		Subjects:   subjects,
+	}
+
+	objects := []client.Object{&serviceAccount, &clusterRoleBinding}
+
+	probeNamespace := getProbeNamespace(config)
+	err = r.ensureNamespace(ctx, probeNamespace)
+	if err != nil {
+		return "", err
+	}
+
+	// The probe namespace's binding also grants access to the namespace object itself, and
+	// only to that one, as a role binding's namespace scopes the requests to its namespace.
+	bindings := []runRoleBinding{
+		{namespace: runCR.Namespace, clusterRoleName: definitions.RunSideCarRoleName, suffix: "sidecar"},
+		{namespace: probeNamespace, clusterRoleName: definitions.RunProbeNamespaceRoleName, suffix: "probe"},
+	}
+	for _, namespace := range getTargetNamespaces(config) {
+		bindings = append(bindings, runRoleBinding{namespace: namespace, clusterRoleName: definitions.RunNamespaceTesterRoleName, suffix: "tester"})
+	}
+
+	for _, binding := range bindings {
+		roleBinding := rbacv1.RoleBinding{
+			ObjectMeta: *objectMeta.DeepCopy(),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: binding.clusterRoleName},
+			Subjects:   subjects,
+		}
+		roleBinding.Name = name + "-" + binding.suffix
+		roleBinding.Namespace = binding.namespace
+
+		objects = append(objects, &roleBinding)
+	}
+
+	for _, obj := range objects {
+		err = r.Create(ctx, obj)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create %T %s (ns %s): %w", obj, obj.GetName(), obj.GetNamespace(), err)
+		}
+	}
+
+	return name, nil
+}
+
+// Creates the namespace in case it doesn't exist.
+func (r *CnfCertificationSuiteRunReconciler) ensureNamespace(ctx context.Context, namespace string) error {
+	err := r.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+// Removes the RBAC resources generated for a run CR. The role bindings may live in namespaces
+// not watched by the controller, so they're listed directly from the API server.
+func (r *CnfCertificationSuiteRunReconciler) deleteRunRBAC(ctx context.Context, runCrNamespacedName types.NamespacedName, jobNamespace string) error {
+	if !generateRunRbac {
+		return nil
+	}
+
+	name := getRunResourcesName(runCrNamespacedName)
+	runLabels := client.MatchingLabels(getRunLabels(runCrNamespacedName))
+
+	objects := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: jobNamespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}},
+	}
+
+	roleBindings := rbacv1.RoleBindingList{}
+	err := r.APIReader.List(ctx, &roleBindings, runLabels)
+	if err != nil {
+		return fmt.Errorf("failed to list role bindings of run %s: %w", runCrNamespacedName, err)
+	}
+	for i := range roleBindings.Items {
+		objects = append(objects, &roleBindings.Items[i])
+	}
+
+	for _, obj := range objects {
+		err = r.Delete(ctx, obj)
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %T %s (ns %s): %w", obj, obj.GetName(), obj.GetNamespace(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/controller/definitions"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Prefix added by kustomize to the names of the cluster roles in config/rbac.
+const rbacNamePrefix = "cnf-certsuite-"
+
+// Returns the cluster roles installed for the generated run service accounts, by name.
+func getRunClusterRoles(t *testing.T) map[string]rbacv1.ClusterRole {
+	rolesFile, err := os.ReadFile("../../config/rbac/run_rbac_roles.yaml")
+	if err != nil {
+		t.Fatalf("failed to read the run cluster roles: %v", err)
+	}
+
+	roles := map[string]rbacv1.ClusterRole{}
+	for _, doc := range strings.Split(string(rolesFile), "\n---\n") {
+		role := rbacv1.ClusterRole{}
+		if err := yaml.Unmarshal([]byte(doc), &role); err != nil {
+			t.Fatalf("failed to parse the run cluster roles: %v", err)
+		}
+		if role.Name != "" {
+			roles[rbacNamePrefix+role.Name] = role
+		}
+	}
+	return roles
+}
+
+func ruleAllows(rule *rbacv1.PolicyRule, apiGroup, resource, verb string) bool {
+	matches := func(values []string, value string) bool {
+		return slices.Contains(values, value) || slices.Contains(values, rbacv1.ResourceAll)
+	}
+	return matches(rule.APIGroups, apiGroup) && matches(rule.Resources, resource) && matches(rule.Verbs, verb)
+}
+
+func Test_runSideCarRoles(t *testing.T) {
+	// Calls made by the sidecar app, and the cluster role expected to allow each of them.
+	tests := []struct {
+		name            string
+		clusterRoleName string
+		apiGroup        string
+		resource        string
+		verb            string
+	}{
+		{
+			name:            "Get the run CR",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "cnf-certifications.redhat.com",
+			resource:        "cnfcertificationsuiteruns",
+			verb:            "get",
+		},
+		{
+			name:            "Update the run CR's status",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "cnf-certifications.redhat.com",
+			resource:        "cnfcertificationsuiteruns/status",
+			verb:            "update",
+		},
+		{
+			name:            "Patch the run CR's progress and export status",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "cnf-certifications.redhat.com",
+			resource:        "cnfcertificationsuiteruns/status",
+			verb:            "patch",
+		},
+		{
+			name:            "List the waivers",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "cnf-certifications.redhat.com",
+			resource:        "cnfcertificationwaivers",
+			verb:            "list",
+		},
+		{
+			name:            "Get the results collector's credentials",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "",
+			resource:        "secrets",
+			verb:            "get",
+		},
+		{
+			name:            "Create events",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "",
+			resource:        "events",
+			verb:            "create",
+		},
+		{
+			name:            "Get the artifacts config map",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "",
+			resource:        "configmaps",
+			verb:            "get",
+		},
+		{
+			name:            "Create the artifacts config map",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "",
+			resource:        "configmaps",
+			verb:            "create",
+		},
+		{
+			name:            "Update the artifacts config map",
+			clusterRoleName: definitions.RunSideCarRoleName,
+			apiGroup:        "",
+			resource:        "configmaps",
+			verb:            "update",
+		},
+		{
+			name:            "Get the pod in the job namespace",
+			clusterRoleName: definitions.RunClusterReaderRoleName,
+			apiGroup:        "",
+			resource:        "pods",
+			verb:            "get",
+		},
+	}
+
+	roles := getRunClusterRoles(t)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			role, found := roles[tc.clusterRoleName]
+			if !assert.True(t, found, "cluster role %s not found", tc.clusterRoleName) {
+				return
+			}
+
+			allowed := false
+			for i := range role.Rules {
+				if ruleAllows(&role.Rules[i], tc.apiGroup, tc.resource, tc.verb) {
+					allowed = true
+					break
+				}
+			}
+			assert.True(t, allowed, "cluster role %s doesn't allow %s on %s", tc.clusterRoleName, tc.verb, tc.resource)
+		})
+	}
+}
+
+func Test_getRunResourcesName(t *testing.T) {
+	longName := strings.Repeat("a", 63)
+	tests := []struct {
+		name                string
+		runCrNamespacedName types.NamespacedName
+		wantName            string
+	}{
+		{
+			name:                "Short name",
+			runCrNamespacedName: types.NamespacedName{Name: "cnf-run", Namespace: "cnf-ns"},
+			wantName:            "cnf-ns-cnf-run-2a747e5d",
+		},
+		{
+			name:                "Long name truncated",
+			runCrNamespacedName: types.NamespacedName{Name: longName, Namespace: "cnf-ns"},
+			wantName:            "cnf-ns-" + strings.Repeat("a", 36),
+		},
+		{
+			name:                "Truncated name ending with a dot",
+			runCrNamespacedName: types.NamespacedName{Name: "cnf." + longName, Namespace: strings.Repeat("n", 38)},
+			wantName:            strings.Repeat("n", 38) + "-cnf",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			name := getRunResourcesName(tc.runCrNamespacedName)
+			assert.True(t, strings.HasPrefix(name, tc.wantName), "name %s doesn't start with %s", name, tc.wantName)
+			assert.LessOrEqual(t, len(name+"-offline-db"), validation.DNS1123LabelMaxLength)
+			assert.Empty(t, validation.IsDNS1123Subdomain(name+"-offline-db"))
+		})
+	}
+}
+
+func Test_getRunResourcesNameCollisions(t *testing.T) {
+	// Runs whose namespace and name joined with a dash are the same.
+	name := getRunResourcesName(types.NamespacedName{Name: "b-run", Namespace: "ns-a"})
+	otherName := getRunResourcesName(types.NamespacedName{Name: "a-b-run", Namespace: "ns"})
+	assert.NotEqual(t, name, otherName)
+
+	// Runs whose truncated names are the same.
+	longName := strings.Repeat("a", 63)
+	name = getRunResourcesName(types.NamespacedName{Name: longName + "1", Namespace: "cnf-ns"})
+	otherName = getRunResourcesName(types.NamespacedName{Name: longName + "2", Namespace: "cnf-ns"})
+	assert.NotEqual(t, name, otherName)
+}
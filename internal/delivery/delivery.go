@@ -0,0 +1,45 @@
+// Package delivery holds the retries and the response checks shared by the clients of the external
+// HTTP services the results are sent to: the notifications' webhooks and the results' collector.
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Max number of bytes of the responses included in the errors.
+const maxErrorBodySize = 512
+
+// Retry calls send until it succeeds or the max number of attempts is reached, waiting between
+// attempts for the backoff, which is doubled every time. Returns the number of attempts and the
+// last error.
+func Retry(ctx context.Context, maxAttempts int, backoff time.Duration, send func(ctx context.Context) error) (attempts int, err error) {
+	for attempts = 1; ; attempts++ {
+		err = send(ctx)
+		if err == nil || attempts >= maxAttempts {
+			return attempts, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempts, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// CheckResponse returns an error in case the response's status is not successful, with the
+// beginning of its body, e.g. "collector returned 401 Unauthorized: invalid password".
+func CheckResponse(service string, resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return fmt.Errorf("%s returned %s: %s", service, resp.Status, strings.TrimSpace(string(respBody)))
+}
@@ -0,0 +1,64 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a send function failing the first n calls.
+func failingSend(n int) func(ctx context.Context) error {
+	calls := 0
+	return func(ctx context.Context) error {
+		calls++
+		if calls <= n {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	// Succeeds after retrying.
+	attempts, err := Retry(context.TODO(), 3, time.Millisecond, failingSend(2))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// Gives up after the max attempts.
+	attempts, err = Retry(context.TODO(), 2, time.Millisecond, failingSend(2))
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, 2, attempts)
+
+	// Stops retrying once the context is done.
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	attempts, err = Retry(ctx, 3, time.Hour, failingSend(3))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "last error: unavailable")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestCheckResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, strings.Repeat("x", 2*maxErrorBodySize), http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Nil(t, CheckResponse("webhook", resp))
+
+	resp, err = http.Get(server.URL + "/fail")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.EqualError(t, CheckResponse("webhook", resp), "webhook returned 503 Service Unavailable: "+strings.Repeat("x", maxErrorBodySize))
+}
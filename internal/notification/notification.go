@@ -0,0 +1,120 @@
+// Package notification sends the results of the finished CNF Certification Suite runs to
+// generic HTTP webhooks, Slack/Teams-compatible incoming webhooks and SMTP servers.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/delivery"
+)
+
+// Max number of failed test cases included in the messages.
+const maxTopFailures = 5
+
+// Verdict of the runs that finished without report.
+const noReportVerdict = cnfcertificationsv1alpha1.StatusVerdictError
+
+const defaultTemplate = `CNF Certification run {{.Namespace}}/{{.Name}} finished with verdict "{{.Verdict}}" (phase {{.Phase}}).
+{{- if .HasReport}}
+Summary: {{.Summary.Total}} test cases, {{.Summary.Passed}} passed, {{.Summary.Skipped}} skipped, {{.Summary.Failed}} failed, {{.Summary.Errored}} errored, {{.Summary.Waived}} waived.
+{{- else}}
+No results were published.
+{{- end}}
+{{- if .TopFailures}}
+Top failures:
+{{- range .TopFailures}}
+- {{.TestCaseName}} ({{.Result}}){{if .Reason}}: {{.Reason}}{{end}}
+{{- end}}
+{{- end}}
+`
+
+// Failure holds a failed or errored test case of a run.
+type Failure struct {
+	TestCaseName string `json:"testCaseName"`
+	Result       string `json:"result"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// Message holds the data of a finished run that is sent to the targets, and used to render the
+// messages' templates.
+type Message struct {
+	Name        string                                                             `json:"name"`
+	Namespace   string                                                             `json:"namespace"`
+	Phase       string                                                             `json:"phase"`
+	Verdict     string                                                             `json:"verdict"`
+	HasReport   bool                                                               `json:"-"`
+	Summary     cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary `json:"summary"`
+	TopFailures []Failure                                                          `json:"topFailures,omitempty"`
+	// Text holds the rendered message.
+	Text string `json:"text"`
+}
+
+// NewMessage returns the message about a finished run. Runs without report get the "error" verdict.
+func NewMessage(runCR *cnfcertificationsv1alpha1.CnfCertificationSuiteRun) *Message {
+	msg := Message{
+		Name:      runCR.Name,
+		Namespace: runCR.Namespace,
+		Phase:     string(runCR.Status.Phase),
+		Verdict:   noReportVerdict,
+	}
+
+	report := runCR.Status.Report
+	if report == nil {
+		return &msg
+	}
+
+	msg.HasReport = true
+	msg.Verdict = report.Verdict
+	msg.Summary = report.Summary
+	for i := range report.Results {
+		result := &report.Results[i]
+		if result.Result != cnfcertificationsv1alpha1.StatusStateFailed && result.Result != cnfcertificationsv1alpha1.StatusStateError {
+			continue
+		}
+		if len(msg.TopFailures) == maxTopFailures {
+			break
+		}
+		msg.TopFailures = append(msg.TopFailures, Failure{TestCaseName: result.TestCaseName, Result: result.Result, Reason: result.Reason})
+	}
+
+	return &msg
+}
+
+// Render sets the message's text with the given Go template, or the default one if empty.
+func (m *Message) Render(tmpl string) error {
+	if tmpl == "" {
+		tmpl = defaultTemplate
+	}
+
+	t, err := template.New("message").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid message template: %w", err)
+	}
+
+	text := bytes.Buffer{}
+	err = t.Execute(&text, m)
+	if err != nil {
+		return fmt.Errorf("failed to render message template: %w", err)
+	}
+
+	m.Text = text.String()
+	return nil
+}
+
+// Sender sends the messages to a target.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Deliver sends the message, retrying with an exponential backoff until it succeeds or the max
+// number of attempts is reached. Returns the number of attempts and the last error.
+func Deliver(ctx context.Context, sender Sender, msg *Message, maxAttempts int, backoff time.Duration) (attempts int, err error) {
+	return delivery.Retry(ctx, maxAttempts, backoff, func(ctx context.Context) error {
+		return sender.Send(ctx, msg)
+	})
+}
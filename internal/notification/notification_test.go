@@ -0,0 +1,177 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func newFinishedRun() *cnfcertificationsv1alpha1.CnfCertificationSuiteRun {
+	return &cnfcertificationsv1alpha1.CnfCertificationSuiteRun{
+		ObjectMeta: v1.ObjectMeta{Name: "cnf-run", Namespace: "cnf-ns"},
+		Status: cnfcertificationsv1alpha1.CnfCertificationSuiteRunStatus{
+			Phase: cnfcertificationsv1alpha1.StatusPhaseCertSuiteFinished,
+			Report: &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+				Verdict: cnfcertificationsv1alpha1.StatusVerdictFail,
+				Summary: cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary{Total: 3, Passed: 1, Failed: 1, Errored: 1},
+				Results: []cnfcertificationsv1alpha1.TestCaseResult{
+					{TestCaseName: "observability-crd-status", Result: cnfcertificationsv1alpha1.StatusStatePassed},
+					{TestCaseName: "observability-pod-disruption-budget", Result: cnfcertificationsv1alpha1.StatusStateFailed, Reason: "no PDB"},
+					{TestCaseName: "observability-container-logging", Result: cnfcertificationsv1alpha1.StatusStateError},
+				},
+			},
+		},
+	}
+}
+
+func TestMessageRender(t *testing.T) {
+	msg := NewMessage(newFinishedRun())
+	assert.Nil(t, msg.Render(""))
+	assert.Equal(t, `CNF Certification run cnf-ns/cnf-run finished with verdict "fail" (phase CertSuiteFinished).
+Summary: 3 test cases, 1 passed, 0 skipped, 1 failed, 1 errored, 0 waived.
+Top failures:
+- observability-pod-disruption-budget (failed): no PDB
+- observability-container-logging (error)
+`, msg.Text)
+
+	// Runs without report are notified with the error verdict.
+	run := newFinishedRun()
+	run.Status.Phase = cnfcertificationsv1alpha1.StatusPhaseCertSuiteError
+	run.Status.Report = nil
+	msg = NewMessage(run)
+	assert.Nil(t, msg.Render("{{.Name}}: {{.Verdict}}"))
+	assert.Equal(t, "cnf-run: error", msg.Text)
+
+	assert.NotNil(t, msg.Render("{{.Unknown}}"))
+	assert.NotNil(t, msg.Render("{{.Name"))
+}
+
+func TestWebhookSenders(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = map[string]interface{}{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	msg := NewMessage(newFinishedRun())
+	assert.Nil(t, msg.Render("{{.Verdict}}"))
+
+	sender, err := NewSender(&Target{Type: cnfcertificationsv1alpha1.NotifierTargetTypeWebhook, URL: server.URL})
+	assert.Nil(t, err)
+	assert.Nil(t, sender.Send(context.TODO(), msg))
+	assert.Equal(t, "cnf-run", received["name"])
+	assert.Equal(t, "fail", received["verdict"])
+	assert.Len(t, received["topFailures"], 2)
+
+	sender, err = NewSender(&Target{Type: cnfcertificationsv1alpha1.NotifierTargetTypeSlack, URL: server.URL})
+	assert.Nil(t, err)
+	assert.Nil(t, sender.Send(context.TODO(), msg))
+	assert.Equal(t, map[string]interface{}{"text": "fail"}, received)
+
+	_, err = NewSender(&Target{Type: "pager"})
+	assert.NotNil(t, err)
+}
+
+func TestDeliver(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first two requests.
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(&Target{Type: cnfcertificationsv1alpha1.NotifierTargetTypeTeams, URL: server.URL})
+	assert.Nil(t, err)
+
+	attempts, err := Deliver(context.TODO(), sender, NewMessage(newFinishedRun()), 3, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+
+	atomic.StoreInt32(&requests, 0)
+	attempts, err = Deliver(context.TODO(), sender, NewMessage(newFinishedRun()), 2, time.Millisecond)
+	assert.ErrorContains(t, err, "503 Service Unavailable: try later")
+	assert.Equal(t, 2, attempts)
+}
+
+// Serves a single SMTP session without extensions, returning the received email through the channel.
+func startFakeSMTPServer(t *testing.T) (addr string, emails <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	received := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 fake SMTP server")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO", "MAIL", "RCPT":
+				_ = tp.PrintfLine("250 OK")
+			case "DATA":
+				_ = tp.PrintfLine("354 Go ahead")
+				data, _ := tp.ReadDotBytes()
+				received <- string(data)
+				_ = tp.PrintfLine("250 Queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				return
+			default:
+				_ = tp.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestEmailSender(t *testing.T) {
+	addr, emails := startFakeSMTPServer(t)
+
+	msg := NewMessage(newFinishedRun())
+	assert.Nil(t, msg.Render("Verdict: {{.Verdict}}"))
+
+	sender, err := NewSender(&Target{
+		Type:       cnfcertificationsv1alpha1.NotifierTargetTypeEmail,
+		SMTPServer: addr,
+		From:       "certsuite@example.com",
+		To:         []string{"cnf-team@example.com", "release@example.com"},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, sender.Send(context.TODO(), msg))
+
+	email, _ := textproto.NewReader(bufio.NewReader(strings.NewReader(<-emails))).ReadMIMEHeader()
+	assert.Equal(t, "CNF Certification run cnf-ns/cnf-run: fail", email.Get("Subject"))
+	assert.Equal(t, "cnf-team@example.com, release@example.com", email.Get("To"))
+
+	// The fake server doesn't support authentication.
+	addr, _ = startFakeSMTPServer(t)
+	sender, _ = NewSender(&Target{Type: cnfcertificationsv1alpha1.NotifierTargetTypeEmail, SMTPServer: addr, From: "a@b.c", To: []string{"d@e.f"}, Username: "user"})
+	assert.NotNil(t, sender.Send(context.TODO(), msg))
+}
@@ -0,0 +1,162 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/delivery"
+)
+
+// Timeout of every attempt to send a message.
+const sendTimeout = 30 * time.Second
+
+// Target holds a notifier's target, with the values of its secrets.
+type Target struct {
+	Type string
+	URL  string
+
+	SMTPServer string
+	From       string
+	To         []string
+	Username   string
+	Password   string
+}
+
+// NewSender returns the sender for the target's type.
+func NewSender(target *Target) (Sender, error) {
+	switch target.Type {
+	case cnfcertificationsv1alpha1.NotifierTargetTypeWebhook:
+		return &webhookSender{url: target.URL, payload: func(msg *Message) interface{} { return msg }}, nil
+	case cnfcertificationsv1alpha1.NotifierTargetTypeSlack, cnfcertificationsv1alpha1.NotifierTargetTypeTeams:
+		// Slack and Teams incoming webhooks accept a json object with the message's text.
+		return &webhookSender{url: target.URL, payload: func(msg *Message) interface{} { return map[string]string{"text": msg.Text} }}, nil
+	case cnfcertificationsv1alpha1.NotifierTargetTypeEmail:
+		return &emailSender{target: target}, nil
+	default:
+		return nil, fmt.Errorf("unknown target type %q", target.Type)
+	}
+}
+
+// webhookSender posts the message as json to an HTTP endpoint.
+type webhookSender struct {
+	url     string
+	payload func(msg *Message) interface{}
+}
+
+func (s *webhookSender) Send(ctx context.Context, msg *Message) error {
+	body, err := json.Marshal(s.payload(msg))
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return delivery.CheckResponse("webhook", resp)
+}
+
+// emailSender sends the message by SMTP, upgrading the connection to TLS if the server supports it.
+type emailSender struct {
+	target *Target
+}
+
+func (s *emailSender) Send(ctx context.Context, msg *Message) error {
+	host, _, err := net.SplitHostPort(s.target.SMTPServer)
+	if err != nil {
+		return fmt.Errorf("invalid smtp server %q: %w", s.target.SMTPServer, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.target.SMTPServer)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err = c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if s.target.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server doesn't support authentication")
+		}
+		err = c.Auth(smtp.PlainAuth("", s.target.Username, s.target.Password, host))
+		if err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	err = c.Mail(s.target.From)
+	if err != nil {
+		return fmt.Errorf("smtp MAIL failed: %w", err)
+	}
+	for _, to := range s.target.To {
+		err = c.Rcpt(to)
+		if err != nil {
+			return fmt.Errorf("smtp RCPT %s failed: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	_, err = w.Write(s.buildEmail(msg))
+	if err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return c.Quit()
+}
+
+func (s *emailSender) buildEmail(msg *Message) []byte {
+	email := strings.Builder{}
+	fmt.Fprintf(&email, "From: %s\r\n", s.target.From)
+	fmt.Fprintf(&email, "To: %s\r\n", strings.Join(s.target.To, ", "))
+	fmt.Fprintf(&email, "Subject: CNF Certification run %s/%s: %s\r\n", msg.Namespace, msg.Name, msg.Verdict)
+	email.WriteString("MIME-Version: 1.0\r\n")
+	email.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	email.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+	return []byte(email.String())
+}
@@ -0,0 +1,180 @@
+// Package junit exports the report of a CNF Certification Suite run as JUnit XML, with a test
+// suite for every certsuite suite.
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+)
+
+// Suite of the test cases whose suite is unknown.
+const unknownSuite = "unknown"
+
+// TestSuites is the root element of the JUnit XML, holding the run's test suites.
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite holds the test cases of a certsuite suite.
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Errors    int        `xml:"errors,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	Time      string     `xml:"time,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// TestCase holds a test case's result. Passed test cases have no failure, error or skipped element.
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Time      string   `xml:"time,attr"`
+	Failure   *Result  `xml:"failure,omitempty"`
+	Error     *Result  `xml:"error,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// Result holds the reason of a failed or errored test case.
+type Result struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Skipped holds the reason of a skipped or waived test case.
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// Returns the suite of the test case result, from the catalog for the reports that don't have it.
+func getSuite(result *cnfcertificationsv1alpha1.TestCaseResult) string {
+	if result.Suite != "" {
+		return result.Suite
+	}
+	if tc, found := catalog.Get(result.TestCaseName); found {
+		return tc.Suite
+	}
+	return unknownSuite
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func newTestCase(suite string, result *cnfcertificationsv1alpha1.TestCaseResult) (tc TestCase, duration time.Duration) {
+	if result.Duration != nil {
+		duration = result.Duration.Duration
+	}
+
+	tc = TestCase{Name: result.TestCaseName, Classname: suite, Time: formatSeconds(duration), SystemOut: result.Logs}
+	switch result.Result {
+	case cnfcertificationsv1alpha1.StatusStateFailed:
+		tc.Failure = &Result{Message: result.Reason, Type: result.Result, Text: getNonCompliantResources(result)}
+	case cnfcertificationsv1alpha1.StatusStateError:
+		tc.Error = &Result{Message: result.Reason, Type: result.Result}
+	case cnfcertificationsv1alpha1.StatusStateSkipped:
+		tc.Skipped = &Skipped{Message: result.Reason}
+	case cnfcertificationsv1alpha1.StatusStateWaived:
+		// Waived failures are accepted, so they're reported as skipped.
+		message := "waived"
+		if result.Waiver != nil {
+			message = fmt.Sprintf("waived by %s (%s): %s", result.Waiver.Name, result.Waiver.Approver, result.Waiver.Justification)
+		}
+		tc.Skipped = &Skipped{Message: message}
+	}
+
+	return tc, duration
+}
+
+// Returns the non-compliant resources of the test case, one per line.
+func getNonCompliantResources(result *cnfcertificationsv1alpha1.TestCaseResult) string {
+	if result.TargetResources == nil {
+		return ""
+	}
+
+	text := ""
+	for _, resource := range result.TargetResources.NonCompliant {
+		keys := make([]string, 0, len(resource))
+		for key := range resource {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		line := ""
+		for _, key := range keys {
+			if line != "" {
+				line += ", "
+			}
+			line += fmt.Sprintf("%s: %s", key, resource[key])
+		}
+		text += line + "\n"
+	}
+	return text
+}
+
+// Export returns the JUnit XML of the report, with the given name. Test suites and test cases are
+// sorted by name.
+func Export(name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+	suites := map[string]*TestSuite{}
+	durations := map[string]time.Duration{}
+	for i := range report.Results {
+		result := &report.Results[i]
+		suiteName := getSuite(result)
+		suite, found := suites[suiteName]
+		if !found {
+			suite = &TestSuite{Name: suiteName}
+			suites[suiteName] = suite
+		}
+
+		tc, duration := newTestCase(suiteName, result)
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Tests++
+		durations[suiteName] += duration
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+
+	testSuites := TestSuites{Name: name}
+	totalDuration := time.Duration(0)
+	for suiteName, suite := range suites {
+		sort.Slice(suite.TestCases, func(i, j int) bool { return suite.TestCases[i].Name < suite.TestCases[j].Name })
+		suite.Time = formatSeconds(durations[suiteName])
+		totalDuration += durations[suiteName]
+
+		testSuites.Tests += suite.Tests
+		testSuites.Failures += suite.Failures
+		testSuites.Errors += suite.Errors
+		testSuites.Skipped += suite.Skipped
+		testSuites.Suites = append(testSuites.Suites, *suite)
+	}
+	sort.Slice(testSuites.Suites, func(i, j int) bool { return testSuites.Suites[i].Name < testSuites.Suites[j].Name })
+	testSuites.Time = formatSeconds(totalDuration)
+
+	out, err := xml.MarshalIndent(&testSuites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal junit xml: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,92 @@
+package junit
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func TestExport(t *testing.T) {
+	report := &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{
+				TestCaseName: "observability-pod-disruption-budget",
+				Suite:        "observability",
+				Result:       cnfcertificationsv1alpha1.StatusStateFailed,
+				Reason:       "no PDB",
+				Duration:     &v1.Duration{Duration: 2 * time.Second},
+				TargetResources: &cnfcertificationsv1alpha1.TargetResources{
+					NonCompliant: []cnfcertificationsv1alpha1.TargetResource{{"kind": "Deployment", "name": "test", "namespace": "tnf"}},
+				},
+			},
+			{
+				TestCaseName: "observability-crd-status",
+				Suite:        "observability",
+				Result:       cnfcertificationsv1alpha1.StatusStatePassed,
+				Duration:     &v1.Duration{Duration: time.Second},
+			},
+			{TestCaseName: "operator-install-source", Suite: "operator", Result: cnfcertificationsv1alpha1.StatusStateSkipped, Reason: "no matching labels"},
+			{
+				TestCaseName: "operator-install-status",
+				Suite:        "operator",
+				Result:       cnfcertificationsv1alpha1.StatusStateWaived,
+				Waiver:       &cnfcertificationsv1alpha1.TestCaseWaiver{Name: "known-issue", Approver: "qa", Justification: "tracked"},
+			},
+			{TestCaseName: "networking-icmpv4-connectivity", Suite: "networking", Result: cnfcertificationsv1alpha1.StatusStateError, Reason: "panic"},
+		},
+	}
+
+	out, err := Export("cnf-run", report)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(out), xml.Header))
+
+	testSuites := TestSuites{}
+	assert.Nil(t, xml.Unmarshal(out, &testSuites))
+	assert.Equal(t, "cnf-run", testSuites.Name)
+	assert.Equal(t, 5, testSuites.Tests)
+	assert.Equal(t, 1, testSuites.Failures)
+	assert.Equal(t, 1, testSuites.Errors)
+	assert.Equal(t, 2, testSuites.Skipped)
+	assert.Equal(t, "3.000", testSuites.Time)
+
+	// Suites and test cases are sorted by name.
+	assert.Len(t, testSuites.Suites, 3)
+	assert.Equal(t, "networking", testSuites.Suites[0].Name)
+	observability := testSuites.Suites[1]
+	assert.Equal(t, "observability", observability.Name)
+	assert.Equal(t, 2, observability.Tests)
+	assert.Equal(t, 1, observability.Failures)
+	assert.Equal(t, "3.000", observability.Time)
+	assert.Equal(t, "observability-crd-status", observability.TestCases[0].Name)
+	assert.Nil(t, observability.TestCases[0].Failure)
+
+	failed := observability.TestCases[1]
+	assert.Equal(t, "observability", failed.Classname)
+	assert.Equal(t, "2.000", failed.Time)
+	assert.Equal(t, "no PDB", failed.Failure.Message)
+	assert.Equal(t, "kind: Deployment, name: test, namespace: tnf\n", failed.Failure.Text)
+
+	operator := testSuites.Suites[2]
+	assert.Equal(t, "no matching labels", operator.TestCases[0].Skipped.Message)
+	assert.Equal(t, "waived by known-issue (qa): tracked", operator.TestCases[1].Skipped.Message)
+	assert.Equal(t, "panic", testSuites.Suites[0].TestCases[0].Error.Message)
+}
+
+func TestExportUnknownSuite(t *testing.T) {
+	report := &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{{TestCaseName: "not-in-catalog", Result: cnfcertificationsv1alpha1.StatusStatePassed}},
+	}
+
+	out, err := Export("cnf-run", report)
+	assert.Nil(t, err)
+
+	testSuites := TestSuites{}
+	assert.Nil(t, xml.Unmarshal(out, &testSuites))
+	assert.Equal(t, unknownSuite, testSuites.Suites[0].Name)
+}
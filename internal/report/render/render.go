@@ -0,0 +1,196 @@
+// Package render turns the report of a CNF Certification Suite run into human-readable documents:
+// a self-contained HTML page and a Markdown summary, that can be attached to certification tickets.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+const (
+	htmlTemplateFile     = "templates/report.html.tmpl"
+	markdownTemplateFile = "templates/report.md.tmpl"
+	unknownSuite         = "unknown"
+)
+
+var (
+	htmlTemplate     = htmltemplate.Must(htmltemplate.ParseFS(templatesFS, htmlTemplateFile))
+	markdownFuncs    = texttemplate.FuncMap{"cell": markdownCell}
+	markdownTemplate = texttemplate.Must(texttemplate.New("report.md.tmpl").Funcs(markdownFuncs).ParseFS(templatesFS, markdownTemplateFile))
+)
+
+// Suite holds the results of a certsuite suite's test cases.
+type Suite struct {
+	Name      string
+	Summary   cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary
+	TestCases []cnfcertificationsv1alpha1.TestCaseResult
+}
+
+// Failure holds a failed or waived test case, with the catalog's remediation and its
+// non-compliant resources.
+type Failure struct {
+	TestCaseName          string
+	Suite                 string
+	Result                string
+	Reason                string
+	Description           string
+	Remediation           string
+	BestPracticeReference string
+	Waiver                *cnfcertificationsv1alpha1.TestCaseWaiver
+	NonCompliant          []string
+}
+
+// View holds the data the templates are rendered with.
+type View struct {
+	Name                string
+	Namespace           string
+	Verdict             string
+	OcpVersion          string
+	CnfCertSuiteVersion string
+	Summary             cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary
+	Suites              []Suite
+	Failures            []Failure
+	Errors              []cnfcertificationsv1alpha1.TestCaseResult
+}
+
+// Returns the suite of the test case result, from the catalog for the reports that don't have it.
+func getSuite(result *cnfcertificationsv1alpha1.TestCaseResult) string {
+	if result.Suite != "" {
+		return result.Suite
+	}
+	if tc, found := catalog.Get(result.TestCaseName); found {
+		return tc.Suite
+	}
+	return unknownSuite
+}
+
+func addToSummary(summary *cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary, result string) {
+	summary.Total++
+	switch result {
+	case cnfcertificationsv1alpha1.StatusStatePassed:
+		summary.Passed++
+	case cnfcertificationsv1alpha1.StatusStateSkipped:
+		summary.Skipped++
+	case cnfcertificationsv1alpha1.StatusStateFailed:
+		summary.Failed++
+	case cnfcertificationsv1alpha1.StatusStateError:
+		summary.Errored++
+	case cnfcertificationsv1alpha1.StatusStateWaived:
+		summary.Waived++
+	}
+}
+
+// Returns a resource's fields as a single line, sorted by key.
+func formatResource(resource cnfcertificationsv1alpha1.TargetResource) string {
+	keys := make([]string, 0, len(resource))
+	for key := range resource {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("%s: %s", key, resource[key]))
+	}
+	return strings.Join(fields, ", ")
+}
+
+func newFailure(suite string, result *cnfcertificationsv1alpha1.TestCaseResult) Failure {
+	failure := Failure{
+		TestCaseName: result.TestCaseName,
+		Suite:        suite,
+		Result:       result.Result,
+		Reason:       result.Reason,
+		Waiver:       result.Waiver,
+	}
+	if info := result.CatalogInfo; info != nil {
+		failure.Description = info.Description
+		failure.Remediation = info.Remediation
+		failure.BestPracticeReference = info.BestPracticeReference
+	}
+	if result.TargetResources != nil {
+		for _, resource := range result.TargetResources.NonCompliant {
+			failure.NonCompliant = append(failure.NonCompliant, formatResource(resource))
+		}
+	}
+	return failure
+}
+
+// NewView returns the view of the run's report. Suites and test cases are sorted by name.
+func NewView(namespace, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) *View {
+	view := View{
+		Name:                name,
+		Namespace:           namespace,
+		Verdict:             report.Verdict,
+		OcpVersion:          report.OcpVersion,
+		CnfCertSuiteVersion: report.CnfCertSuiteVersion,
+		Summary:             report.Summary,
+	}
+
+	results := append([]cnfcertificationsv1alpha1.TestCaseResult{}, report.Results...)
+	sort.Slice(results, func(i, j int) bool { return results[i].TestCaseName < results[j].TestCaseName })
+
+	suites := map[string]*Suite{}
+	for i := range results {
+		result := &results[i]
+		suiteName := getSuite(result)
+		suite, found := suites[suiteName]
+		if !found {
+			suite = &Suite{Name: suiteName}
+			suites[suiteName] = suite
+		}
+		suite.TestCases = append(suite.TestCases, *result)
+		addToSummary(&suite.Summary, result.Result)
+
+		switch result.Result {
+		case cnfcertificationsv1alpha1.StatusStateFailed, cnfcertificationsv1alpha1.StatusStateWaived:
+			view.Failures = append(view.Failures, newFailure(suiteName, result))
+		case cnfcertificationsv1alpha1.StatusStateError:
+			view.Errors = append(view.Errors, *result)
+		}
+	}
+
+	for _, suite := range suites {
+		view.Suites = append(view.Suites, *suite)
+	}
+	sort.Slice(view.Suites, func(i, j int) bool { return view.Suites[i].Name < view.Suites[j].Name })
+
+	return &view
+}
+
+// Escapes the text to be set in a Markdown table's cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// HTML returns the report as a self-contained HTML page.
+func HTML(namespace, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+	buf := bytes.Buffer{}
+	err := htmlTemplate.Execute(&buf, NewView(namespace, name, report))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render html report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Markdown returns the report's Markdown summary.
+func Markdown(namespace, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+	buf := bytes.Buffer{}
+	err := markdownTemplate.Execute(&buf, NewView(namespace, name, report))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render markdown report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
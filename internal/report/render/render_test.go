@@ -0,0 +1,79 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func newReport() *cnfcertificationsv1alpha1.CnfCertificationSuiteReport {
+	return &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		Verdict:             cnfcertificationsv1alpha1.StatusVerdictFail,
+		OcpVersion:          "4.16.0",
+		CnfCertSuiteVersion: "v5.2.0",
+		Summary:             cnfcertificationsv1alpha1.CnfCertificationSuiteReportStatusSummary{Total: 4, Passed: 1, Failed: 1, Errored: 1, Waived: 1},
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{TestCaseName: "observability-crd-status", Suite: "observability", Result: cnfcertificationsv1alpha1.StatusStatePassed},
+			{
+				TestCaseName: "observability-pod-disruption-budget",
+				Suite:        "observability",
+				Result:       cnfcertificationsv1alpha1.StatusStateFailed,
+				Reason:       "no <PDB>",
+				TargetResources: &cnfcertificationsv1alpha1.TargetResources{
+					NonCompliant: []cnfcertificationsv1alpha1.TargetResource{{"Namespace": "tnf", "Deployment Name": "test"}},
+				},
+				CatalogInfo: &cnfcertificationsv1alpha1.TestCaseCatalogInfo{Remediation: "Add a PDB", BestPracticeReference: "https://example.com/pdb"},
+			},
+			{TestCaseName: "access-control-container-host-port", Result: cnfcertificationsv1alpha1.StatusStateWaived,
+				Waiver: &cnfcertificationsv1alpha1.TestCaseWaiver{Name: "known-issue", Approver: "qa", Justification: "tracked"}},
+			{TestCaseName: "networking-icmpv4-connectivity", Suite: "networking", Result: cnfcertificationsv1alpha1.StatusStateError, Reason: "a | b"},
+		},
+	}
+}
+
+func TestNewView(t *testing.T) {
+	view := NewView("cnf-ns", "cnf-run", newReport())
+
+	assert.Len(t, view.Suites, 3)
+	assert.Equal(t, "access-control", view.Suites[0].Name)
+	assert.Equal(t, "observability", view.Suites[2].Name)
+	assert.Equal(t, 2, view.Suites[2].Summary.Total)
+	assert.Equal(t, 1, view.Suites[2].Summary.Failed)
+
+	assert.Len(t, view.Failures, 2)
+	assert.Equal(t, "access-control-container-host-port", view.Failures[0].TestCaseName)
+	assert.Equal(t, "known-issue", view.Failures[0].Waiver.Name)
+	assert.Equal(t, "Add a PDB", view.Failures[1].Remediation)
+	assert.Equal(t, []string{"Deployment Name: test, Namespace: tnf"}, view.Failures[1].NonCompliant)
+	assert.Len(t, view.Errors, 1)
+}
+
+func TestHTML(t *testing.T) {
+	out, err := HTML("cnf-ns", "cnf-run", newReport())
+	assert.Nil(t, err)
+
+	html := string(out)
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, `Verdict: <span class="fail">fail</span>`)
+	assert.Contains(t, html, `<tr><th>Remediation</th><td>Add a PDB</td></tr>`)
+	assert.Contains(t, html, `<a href="https://example.com/pdb">`)
+	assert.Contains(t, html, `<li>Deployment Name: test, Namespace: tnf</li>`)
+	// Values are escaped.
+	assert.Contains(t, html, "no &lt;PDB&gt;")
+}
+
+func TestMarkdown(t *testing.T) {
+	out, err := Markdown("cnf-ns", "cnf-run", newReport())
+	assert.Nil(t, err)
+
+	md := string(out)
+	assert.True(t, strings.HasPrefix(md, "# CNF Certification Suite run cnf-ns/cnf-run\n\n**Verdict: fail**"))
+	assert.Contains(t, md, "| 4 | 1 | 0 | 1 | 1 | 1 |")
+	assert.Contains(t, md, "### observability-pod-disruption-budget (failed)\n\n- Suite: observability\n- Reason: no <PDB>\n- Remediation: Add a PDB\n")
+	assert.Contains(t, md, "Non-compliant resources:\n\n- Deployment Name: test, Namespace: tnf\n")
+	assert.Contains(t, md, "- Waived by known-issue (qa): tracked")
+	assert.Contains(t, md, `| networking-icmpv4-connectivity | error | a \| b |`)
+}
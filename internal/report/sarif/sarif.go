@@ -0,0 +1,257 @@
+// Package sarif exports the non-compliant resources found by a CNF Certification Suite run as SARIF
+// findings, so they can be imported by security and compliance tools like any other static finding.
+// Every failed test case is a rule, with its catalog information, and every non-compliant resource
+// is a result of its rule. Waived test cases' results are reported as suppressed.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/certsuite/catalog"
+)
+
+const (
+	schemaURI      = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion   = "2.1.0"
+	toolName       = "certsuite"
+	toolInfoURI    = "https://github.com/redhat-best-practices-for-k8s/certsuite"
+	levelError     = "error"
+	resourceKind   = "resource"
+	suppressedKind = "external"
+
+	// Keys of the non-compliant resources' fields set by the certsuite.
+	reasonKey    = "Reason For Non Compliance"
+	namespaceKey = "Namespace"
+)
+
+// Log is the root object of a SARIF file.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run holds the rules and results of a run of a tool.
+type Run struct {
+	Tool       Tool              `json:"tool"`
+	Results    []Result          `json:"results"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Tool holds the tool's driver.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the tool and its rules.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes the best practice checked by a test case.
+type Rule struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name"`
+	ShortDescription *Message        `json:"shortDescription,omitempty"`
+	FullDescription  *Message        `json:"fullDescription,omitempty"`
+	Help             *Message        `json:"help,omitempty"`
+	HelpURI          string          `json:"helpUri,omitempty"`
+	Properties       *RuleProperties `json:"properties,omitempty"`
+}
+
+// RuleProperties holds the rule's suite and tags, and the process to get an exception for it.
+type RuleProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	ExceptionProcess string   `json:"exceptionProcess,omitempty"`
+}
+
+// Message holds a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a finding of a rule, i.e. a non-compliant resource.
+type Result struct {
+	RuleID       string            `json:"ruleId"`
+	RuleIndex    int               `json:"ruleIndex"`
+	Level        string            `json:"level"`
+	Message      Message           `json:"message"`
+	Locations    []Location        `json:"locations,omitempty"`
+	Suppressions []Suppression     `json:"suppressions,omitempty"`
+	Properties   map[string]string `json:"properties,omitempty"`
+}
+
+// Location holds the logical location of a non-compliant resource.
+type Location struct {
+	LogicalLocations []LogicalLocation `json:"logicalLocations"`
+}
+
+// LogicalLocation identifies a non-compliant resource in the cluster.
+type LogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Suppression holds the waiver of a result.
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func newRule(result *cnfcertificationsv1alpha1.TestCaseResult) Rule {
+	rule := Rule{ID: result.TestCaseName, Name: result.TestCaseName}
+
+	suite := result.Suite
+	tags := []string{}
+	if tc, found := catalog.Get(result.TestCaseName); found {
+		if suite == "" {
+			suite = tc.Suite
+		}
+		tags = append(tags, tc.Tags...)
+	}
+	if suite != "" {
+		tags = append([]string{suite}, tags...)
+	}
+	rule.Properties = &RuleProperties{Tags: tags}
+
+	if info := result.CatalogInfo; info != nil {
+		if info.Description != "" {
+			rule.ShortDescription = &Message{Text: info.Description}
+			rule.FullDescription = &Message{Text: info.Description}
+		}
+		if info.Remediation != "" {
+			rule.Help = &Message{Text: info.Remediation}
+		}
+		rule.HelpURI = info.BestPracticeReference
+		rule.Properties.ExceptionProcess = info.ExceptionProcess
+	}
+
+	return rule
+}
+
+// Returns the sorted keys of a resource's fields.
+func getSortedKeys(resource cnfcertificationsv1alpha1.TargetResource) []string {
+	keys := make([]string, 0, len(resource))
+	for key := range resource {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns the location of a non-compliant resource. Its fully qualified name is made of its
+// namespace, if any, and the rest of its identifying fields.
+func newLocation(resource cnfcertificationsv1alpha1.TargetResource) Location {
+	name := ""
+	for _, key := range getSortedKeys(resource) {
+		if key == reasonKey || key == namespaceKey {
+			continue
+		}
+		if name != "" {
+			name += "/"
+		}
+		name += resource[key]
+	}
+
+	fullyQualifiedName := name
+	if namespace := resource[namespaceKey]; namespace != "" {
+		fullyQualifiedName = namespace + "/" + name
+	}
+
+	return Location{LogicalLocations: []LogicalLocation{{Name: name, FullyQualifiedName: fullyQualifiedName, Kind: resourceKind}}}
+}
+
+func newResults(ruleIndex int, result *cnfcertificationsv1alpha1.TestCaseResult) []Result {
+	var suppressions []Suppression
+	if result.Result == cnfcertificationsv1alpha1.StatusStateWaived && result.Waiver != nil {
+		suppressions = []Suppression{{
+			Kind:          suppressedKind,
+			Status:        "accepted",
+			Justification: fmt.Sprintf("waived by %s (%s): %s", result.Waiver.Name, result.Waiver.Approver, result.Waiver.Justification),
+		}}
+	}
+
+	var nonCompliant []cnfcertificationsv1alpha1.TargetResource
+	if result.TargetResources != nil {
+		nonCompliant = result.TargetResources.NonCompliant
+	}
+
+	// Failed test cases without non-compliant resources are reported as a single result.
+	if len(nonCompliant) == 0 {
+		return []Result{{
+			RuleID:       result.TestCaseName,
+			RuleIndex:    ruleIndex,
+			Level:        levelError,
+			Message:      Message{Text: getMessage(result, nil)},
+			Suppressions: suppressions,
+		}}
+	}
+
+	results := []Result{}
+	for _, resource := range nonCompliant {
+		results = append(results, Result{
+			RuleID:       result.TestCaseName,
+			RuleIndex:    ruleIndex,
+			Level:        levelError,
+			Message:      Message{Text: getMessage(result, resource)},
+			Locations:    []Location{newLocation(resource)},
+			Suppressions: suppressions,
+			Properties:   resource,
+		})
+	}
+	return results
+}
+
+// Returns the message of a result: the resource's reason for non-compliance if set, or the test
+// case's failure reason otherwise.
+func getMessage(result *cnfcertificationsv1alpha1.TestCaseResult, resource cnfcertificationsv1alpha1.TargetResource) string {
+	if reason := resource[reasonKey]; reason != "" {
+		return reason
+	}
+	if result.Reason != "" {
+		return result.Reason
+	}
+	return fmt.Sprintf("test case %s failed", result.TestCaseName)
+}
+
+// Export returns the SARIF log of the report's failed and waived test cases, with the given run's
+// namespaced name as properties.
+func Export(namespace, name string, report *cnfcertificationsv1alpha1.CnfCertificationSuiteReport) ([]byte, error) {
+	failed := []*cnfcertificationsv1alpha1.TestCaseResult{}
+	for i := range report.Results {
+		result := &report.Results[i]
+		if result.Result == cnfcertificationsv1alpha1.StatusStateFailed || result.Result == cnfcertificationsv1alpha1.StatusStateWaived {
+			failed = append(failed, result)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].TestCaseName < failed[j].TestCaseName })
+
+	run := Run{
+		Tool: Tool{Driver: Driver{
+			Name:           toolName,
+			Version:        report.CnfCertSuiteVersion,
+			InformationURI: toolInfoURI,
+			Rules:          []Rule{},
+		}},
+		Results:    []Result{},
+		Properties: map[string]string{"namespace": namespace, "name": name, "ocpVersion": report.OcpVersion},
+	}
+	for i, result := range failed {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newRule(result))
+		run.Results = append(run.Results, newResults(i, result)...)
+	}
+
+	out, err := json.MarshalIndent(&Log{Schema: schemaURI, Version: sarifVersion, Runs: []Run{run}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sarif log: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cnfcertificationsv1alpha1 "github.com/redhat-best-practices-for-k8s/certsuite-operator/api/v1alpha1"
+)
+
+func TestExport(t *testing.T) {
+	report := &cnfcertificationsv1alpha1.CnfCertificationSuiteReport{
+		CnfCertSuiteVersion: "v5.2.0",
+		Results: []cnfcertificationsv1alpha1.TestCaseResult{
+			{TestCaseName: "observability-crd-status", Suite: "observability", Result: cnfcertificationsv1alpha1.StatusStatePassed},
+			{
+				TestCaseName: "observability-pod-disruption-budget",
+				Suite:        "observability",
+				Result:       cnfcertificationsv1alpha1.StatusStateFailed,
+				Reason:       "no PDB",
+				TargetResources: &cnfcertificationsv1alpha1.TargetResources{
+					NonCompliant: []cnfcertificationsv1alpha1.TargetResource{
+						{"Namespace": "tnf", "Deployment Name": "test", "Reason For Non Compliance": "Deployment has no PDB"},
+						{"Namespace": "tnf", "StatefulSet Name": "db"},
+					},
+				},
+				CatalogInfo: &cnfcertificationsv1alpha1.TestCaseCatalogInfo{
+					Description:           "Checks the PDBs",
+					Remediation:           "Add a PDB",
+					BestPracticeReference: "https://example.com/pdb",
+				},
+			},
+			{
+				TestCaseName: "access-control-container-host-port",
+				Result:       cnfcertificationsv1alpha1.StatusStateWaived,
+				Reason:       "host port used",
+				Waiver:       &cnfcertificationsv1alpha1.TestCaseWaiver{Name: "known-issue", Approver: "qa", Justification: "tracked"},
+			},
+		},
+	}
+
+	out, err := Export("cnf-ns", "cnf-run", report)
+	assert.Nil(t, err)
+
+	log := Log{}
+	assert.Nil(t, json.Unmarshal(out, &log))
+	assert.Equal(t, sarifVersion, log.Version)
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "v5.2.0", run.Tool.Driver.Version)
+	assert.Equal(t, "cnf-run", run.Properties["name"])
+
+	// Rules are sorted by test case, and take their suite and tags from the catalog if needed.
+	rules := run.Tool.Driver.Rules
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "access-control-container-host-port", rules[0].ID)
+	assert.Equal(t, []string{"access-control", "common"}, rules[0].Properties.Tags)
+	assert.Nil(t, rules[0].Help)
+	assert.Equal(t, "observability-pod-disruption-budget", rules[1].ID)
+	assert.Equal(t, "Checks the PDBs", rules[1].ShortDescription.Text)
+	assert.Equal(t, "Add a PDB", rules[1].Help.Text)
+	assert.Equal(t, "https://example.com/pdb", rules[1].HelpURI)
+
+	// The waived test case without non-compliant resources has a single suppressed result.
+	results := run.Results
+	assert.Len(t, results, 3)
+	assert.Equal(t, 0, results[0].RuleIndex)
+	assert.Equal(t, "host port used", results[0].Message.Text)
+	assert.Empty(t, results[0].Locations)
+	assert.Equal(t, "waived by known-issue (qa): tracked", results[0].Suppressions[0].Justification)
+
+	assert.Equal(t, 1, results[1].RuleIndex)
+	assert.Equal(t, levelError, results[1].Level)
+	assert.Equal(t, "Deployment has no PDB", results[1].Message.Text)
+	assert.Equal(t, "tnf/test", results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Empty(t, results[1].Suppressions)
+	assert.Equal(t, "test", results[1].Properties["Deployment Name"])
+	assert.Equal(t, "no PDB", results[2].Message.Text)
+	assert.Equal(t, "tnf/db", results[2].Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
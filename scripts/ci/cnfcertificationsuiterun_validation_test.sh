@@ -42,6 +42,24 @@ oc apply -f config/samples/validation-test/invalid_run9.yaml && exit_statuses+=(
 # Invalid log level
 oc apply -f config/samples/validation-test/invalid_run10.yaml && exit_statuses+=(0) || exit_statuses+=($?)
 
+# Invalid configmaps' tnf_config.yaml content: unknown field, malformed label and non-existing target namespace
+oc apply -f config/samples/validation-test/configmaps/configmap11.yaml
+oc apply -f config/samples/validation-test/invalid_run11.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+oc apply -f config/samples/validation-test/configmaps/configmap12.yaml
+oc apply -f config/samples/validation-test/invalid_run12.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+oc apply -f config/samples/validation-test/configmaps/configmap13.yaml
+oc apply -f config/samples/validation-test/invalid_run13.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+
+# Invalid labels filter expression
+oc apply -f config/samples/validation-test/invalid_run14.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+
+# Invalid timeout
+oc apply -f config/samples/validation-test/invalid_run15.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+
+# Invalid preflight secret's docker config
+oc apply -f config/samples/validation-test/preflight_secrets/preflight_secret16.yaml
+oc apply -f config/samples/validation-test/invalid_run16.yaml && exit_statuses+=(0) || exit_statuses+=($?)
+
 # Check valid run CR exit status
 if [ "${exit_statuses[0]}" -eq 0 ]; then
     echo "Test passed: valid run sample, has passed validation"
@@ -0,0 +1,127 @@
+// Package fakecollector implements a stand-in of the certsuite's Collector, which stores the
+// results pushed to it in memory, to test the results export without an actual collector.
+package fakecollector
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-operator/internal/collector"
+)
+
+// Max size of the multipart forms accepted by the fake collector.
+const maxFormSize = 32 << 20
+
+// Upload holds the results received in a request.
+type Upload struct {
+	Claim       []byte
+	Report      []byte
+	ExecutedBy  string
+	PartnerName string
+	Password    string
+}
+
+// Collector is an http.Handler that accepts the results posted with the Collector protocol.
+type Collector struct {
+	mu      sync.Mutex
+	uploads []Upload
+	// Number of the next requests that fail with an internal server error.
+	failures int
+	// Password expected in the requests, if set.
+	password string
+}
+
+// New returns a fake collector that accepts every request.
+func New() *Collector {
+	return &Collector{}
+}
+
+// NewServer starts an HTTP server with a fake collector, returning both. The server must be
+// closed by the caller.
+func NewServer() (*httptest.Server, *Collector) {
+	c := New()
+	return httptest.NewServer(c), c
+}
+
+// FailNext makes the next n requests fail, to test the retries.
+func (c *Collector) FailNext(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = n
+}
+
+// RequirePassword makes the requests without the given password be rejected as unauthorized.
+func (c *Collector) RequirePassword(password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.password = password
+}
+
+// Uploads returns the results received so far.
+func (c *Collector) Uploads() []Upload {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Upload{}, c.uploads...)
+}
+
+func readFormFile(r *http.Request, field string) ([]byte, error) {
+	file, _, err := r.FormFile(field)
+	if err == http.ErrMissingFile {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		http.Error(w, "collector unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxFormSize); err != nil {
+		http.Error(w, "invalid form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	upload := Upload{
+		ExecutedBy:  r.FormValue(collector.ExecutedByField),
+		PartnerName: r.FormValue(collector.PartnerNameField),
+		Password:    r.FormValue(collector.PasswordField),
+	}
+	if c.password != "" && upload.Password != c.password {
+		http.Error(w, "invalid password", http.StatusUnauthorized)
+		return
+	}
+
+	var err error
+	upload.Claim, err = readFormFile(r, collector.ClaimFileField)
+	if err == nil {
+		upload.Report, err = readFormFile(r, collector.ReportFileField)
+	}
+	if err != nil {
+		http.Error(w, "invalid form file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if upload.Claim == nil {
+		http.Error(w, "missing claim file", http.StatusBadRequest)
+		return
+	}
+
+	c.uploads = append(c.uploads, upload)
+	w.WriteHeader(http.StatusOK)
+}